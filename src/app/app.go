@@ -6,6 +6,7 @@ package app
 
 import (
 	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -52,7 +53,7 @@ func version(output io.Writer) func() {
 func usage(output io.Writer) func() {
 	return func() {
 		fmt.Fprintf(output, "Usage: %v [OPTION]... [FILE]\n", os.Args[0])
-		fmt.Fprintln(output, "Pretty-print Ansible's --list-tasks output")
+		fmt.Fprintln(output, "Pretty-print Ansible's --list-tasks, --list-tags, --list-hosts and playbook run output")
 		fmt.Fprintln(output)
 		flag.PrintDefaults()
 	}
@@ -60,14 +61,69 @@ func usage(output io.Writer) func() {
 
 func Run(c *Config) int {
 
-	ui.Box = c.AcquireBoxChars()
-	ui.FnWidth = c.Widther.Width
-
 	if c.IsVersion {
 		version(c.OutErr)()
 		return 0
 	}
 
+	if err := c.LoadConfigFile(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateBox(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateColor(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateTheme(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	ui.Box = c.AcquireBoxChars()
+	ui.FnWidth = c.Widther.Width
+
+	if err := c.ValidateFormat(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateFormatIn(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateSanitize(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateTabStop(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateColumnFit(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.ValidateColumns(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
+	if err := c.PlayFilter.Validate(); err != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		return 1
+	}
+
 	scanner, closer, err := c.AcquireScanner()
 	if err != nil {
 		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
@@ -81,18 +137,49 @@ func Run(c *Config) int {
 		return 0
 	}
 
-	result, err := processor.ProcessLines(scanner, c.Widther)
+	pr := processor.NewProcessor()
+	pr.SetWidther(c.Widther)
+	pr.SetFormat(c.AcquireFormatIn())
+	pr.SetIsLenient(c.IsLenient)
+	pr.SetIsKeepColors(c.IsKeepColors)
+	pr.SetSanitize(c.AcquireSanitize())
+	pr.SetTabStop(c.AcquireTabStop())
+
+	result, err := pr.Process(scanner)
 	if err != nil {
-		fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		var parseErrors processor.ParseErrors
+
+		if errors.As(err, &parseErrors) {
+			for _, e := range parseErrors {
+				fmt.Fprintf(c.OutErr, "app.Run: %v\n", e)
+			}
+		} else {
+			fmt.Fprintf(c.OutErr, "app.Run: %v\n", err)
+		}
+
 		return 1
 	}
 
-	if c.IsStats {
+	for _, w := range result.Warnings {
+		fmt.Fprintf(c.OutErr, "app.Run: warning: %v\n", w)
+	}
+
+	if !c.PlayFilter.IsEmpty() {
+		result = result.FilterPlays(c.PlayFilter)
+	}
+
+	// JSON document carries stats on its own
+	if c.IsStats && c.Format != FormatJson {
 		ui.MsgBoxTo(c.Out, result.Stats.Lines())
 	}
 
 	p := c.AcquirePrinter()
 	p.PrintTo(c.Out, result)
 
+	if ep, ok := p.(ErrPrinter); ok && ep.Err() != nil {
+		fmt.Fprintf(c.OutErr, "app.Run: %v\n", ep.Err())
+		return 1
+	}
+
 	return 0
 }
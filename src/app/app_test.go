@@ -5,6 +5,7 @@
 package app
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/keewek/ansible-pretty-print/src/cmn"
 	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+	"github.com/keewek/ansible-pretty-print/src/printer"
+	"github.com/keewek/ansible-pretty-print/src/processor"
 )
 
 var fnTermSize = func(cols int, lines int, err error) TermSizeFunc {
@@ -21,6 +24,12 @@ var fnTermSize = func(cols int, lines int, err error) TermSizeFunc {
 	}
 }
 
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
 func TestRun(t *testing.T) {
 
 	t.Run("scanner error", func(t *testing.T) {
@@ -67,6 +76,149 @@ func TestRun(t *testing.T) {
 
 		tst.DiffError(t, want, got)
 
+		want = "app.Run: line 4, column 3: processor.processPlay: unexpected play format: \"  play #1 (demo): Error TAGS_: []\"\n"
+		got = outErr.String()
+
+		tst.DiffError(t, want, got)
+	})
+
+	t.Run("json output: write error", func(t *testing.T) {
+		var outErr cmn.LineBuilder
+
+		c := &Config{
+			TermWidth: DefaultTermWidth,
+			Format:    FormatJson,
+			Out:       failingWriter{},
+			OutErr:    &outErr,
+			Filepath:  "testdata/list-tasks-1.txt",
+		}
+
+		c.Init(fnTermSize(80, 0, nil))
+		r := Run(c)
+
+		tst.DiffError(t, "Exit code: 1", fmt.Sprintf("Exit code: %v", r))
+		tst.DiffError(t, "app.Run: JsonPrinter.PrintTo: broken pipe\n", outErr.String())
+	})
+
+	t.Run("processor errors: strict", func(t *testing.T) {
+		var (
+			out    cmn.LineBuilder
+			outErr cmn.LineBuilder
+		)
+
+		c := &Config{
+			TermWidth: DefaultTermWidth,
+			Out:       &out,
+			OutErr:    &outErr,
+			Filepath:  "testdata/list-tasks-err-multi.txt",
+		}
+
+		c.Init(fnTermSize(80, 0, nil))
+		r := Run(c)
+
+		want := "Exit code: 1"
+		got := fmt.Sprintf("Exit code: %v", r)
+
+		tst.DiffError(t, want, got)
+
+		var lb cmn.LineBuilder
+		lb.WriteLine("app.Run: line 6, column 7: processor.processTask: unexpected task format: \"      Task 1.1 Error    TAGS_: []\"")
+		lb.WriteLine("app.Run: line 9, column 3: processor.processPlay: unexpected play format: \"  play #2 (demo): Error TAGS_: []\"")
+
+		tst.DiffError(t, lb.String(), outErr.String())
+		tst.DiffError(t, "", out.String())
+	})
+
+	t.Run("processor errors: lenient", func(t *testing.T) {
+		var (
+			out    cmn.LineBuilder
+			outErr cmn.LineBuilder
+		)
+
+		c := &Config{
+			TermWidth: DefaultTermWidth,
+			IsLenient: true,
+			Out:       &out,
+			OutErr:    &outErr,
+			Filepath:  "testdata/list-tasks-err-multi.txt",
+		}
+
+		c.Init(fnTermSize(80, 0, nil))
+		r := Run(c)
+
+		want := "Exit code: 0"
+		got := fmt.Sprintf("Exit code: %v", r)
+
+		tst.DiffError(t, want, got)
+
+		var lb cmn.LineBuilder
+		lb.WriteLine("app.Run: warning: line 6, column 7: processor.processTask: unexpected task format: \"      Task 1.1 Error    TAGS_: []\"")
+		lb.WriteLine("app.Run: warning: line 9, column 3: processor.processPlay: unexpected play format: \"  play #2 (demo): Error TAGS_: []\"")
+
+		tst.DiffError(t, lb.String(), outErr.String())
+
+		wantOut, err := os.ReadFile("testdata/out-list-tasks-err-multi-lenient.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tst.DiffError(t, string(wantOut), out.String())
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		var (
+			out    cmn.LineBuilder
+			outErr cmn.LineBuilder
+		)
+
+		c := &Config{
+			TermWidth: DefaultTermWidth,
+			Format:    "xml",
+			Out:       &out,
+			OutErr:    &outErr,
+			Filepath:  "testdata/list-tasks-1.txt",
+		}
+
+		c.Init(fnTermSize(80, 0, nil))
+		r := Run(c)
+
+		want := "Exit code: 1"
+		got := fmt.Sprintf("Exit code: %v", r)
+
+		tst.DiffError(t, want, got)
+
+		want = "app.Run: Config.ValidateFormat: unknown output format \"xml\"\n"
+		got = outErr.String()
+
+		tst.DiffError(t, want, got)
+	})
+
+	t.Run("bad play filter", func(t *testing.T) {
+		var (
+			out    cmn.LineBuilder
+			outErr cmn.LineBuilder
+		)
+
+		c := &Config{
+			TermWidth:  DefaultTermWidth,
+			PlayFilter: processor.PlayFilter{HostPattern: "web["},
+			Out:        &out,
+			OutErr:     &outErr,
+			Filepath:   "testdata/list-tasks-1.txt",
+		}
+
+		c.Init(fnTermSize(80, 0, nil))
+		r := Run(c)
+
+		want := "Exit code: 1"
+		got := fmt.Sprintf("Exit code: %v", r)
+
+		tst.DiffError(t, want, got)
+
+		want = "app.Run: processor.PlayFilter.Validate: bad host pattern \"web[\": syntax error in pattern\n"
+		got = outErr.String()
+
+		tst.DiffError(t, want, got)
 	})
 
 	t.Run("usage", func(t *testing.T) {
@@ -93,7 +245,7 @@ func TestRun(t *testing.T) {
 		r := Run(c)
 
 		lb.WriteLine(fmt.Sprintf("Usage: %v [OPTION]... [FILE]", os.Args[0]))
-		lb.WriteLine("Pretty-print Ansible's --list-tasks output")
+		lb.WriteLine("Pretty-print Ansible's --list-tasks, --list-tags, --list-hosts and playbook run output")
 		lb.WriteLine("")
 		lb.WriteString(outFlag.String())
 		outErr.WriteString(outFlag.String())
@@ -116,6 +268,7 @@ func TestRun(t *testing.T) {
 
 		type testItem struct {
 			name     string
+			format   string
 			isMono   bool
 			isTable  bool
 			isDos    bool
@@ -137,6 +290,8 @@ func TestRun(t *testing.T) {
 			{name: "mono-chop_80-indent", isChop: true, isIndent: true, isMono: true},
 			{name: "mono-table_80-ascii", isTable: true, isMono: true},
 			{name: "mono-table_80-dos", isTable: true, isDos: true, isMono: true},
+			{name: "json", format: FormatJson},
+			{name: "json-stats", format: FormatJson, isStats: true},
 		}
 
 		for _, ti := range tests {
@@ -147,6 +302,7 @@ func TestRun(t *testing.T) {
 				c := &Config{
 					TermWidth: DefaultTermWidth,
 					// Widther:   cmn.MonospaceWidther{},
+					Format:   ti.format,
 					IsMono:   ti.isMono,
 					IsTable:  ti.isTable,
 					IsDos:    ti.isDos,
@@ -174,6 +330,154 @@ func TestRun(t *testing.T) {
 		}
 	})
 
+	t.Run("listings", func(t *testing.T) {
+		var out cmn.LineBuilder
+
+		type testItem struct {
+			name         string
+			input        string
+			format       string
+			formatIn     string
+			isTable      bool
+			isMono       bool
+			isChop       bool
+			isWideAmbig  bool
+			isWrap       bool
+			isWrapNames  bool
+			isDos        bool
+			isIndent     bool
+			isKeepColors bool
+			isAlignPlays bool
+			isKeepIndent bool
+			playFilter   processor.PlayFilter
+			sanitize     string
+			tabStop      int
+			columns      string
+			isKeepEmpty  bool
+			isPerPlay    bool
+			box          string
+			configPath   string
+			color        string
+			theme        string
+			width        int
+		}
+
+		tests := []testItem{
+			{name: "list-tags", input: "list-tags"},
+			{name: "list-tags-table_80-dos", input: "list-tags", isTable: true, isDos: true},
+			{name: "list-tags-json", input: "list-tags", format: FormatJson},
+			{name: "list-tasks-tags", input: "list-tasks-tags"},
+			{name: "list-tasks-tags-table_80-ascii", input: "list-tasks-tags", isTable: true},
+			{name: "list-hosts", input: "list-hosts"},
+			{name: "list-hosts-table_80-dos", input: "list-hosts", isTable: true, isDos: true},
+			{name: "list-hosts-json", input: "list-hosts", format: FormatJson},
+			{name: "list-all", input: "list-all"},
+			{name: "list-all-dossier_80-dos", input: "list-all", format: FormatDossier, isDos: true},
+			{name: "list-all-json", input: "list-all", format: FormatJson},
+			{name: "list-all-align", input: "list-all", isAlignPlays: true},
+			{name: "list-all-table_80-align", input: "list-all", isTable: true, isAlignPlays: true},
+			{name: "list-all-play_hosts", input: "list-all", playFilter: processor.PlayFilter{HostPattern: "d*"}},
+			{name: "list-reindent", input: "list-reindent"},
+			{name: "list-reindent-keep_indent", input: "list-reindent", isKeepIndent: true},
+			{name: "list-reindent-table_80-keep_indent", input: "list-reindent", isTable: true, isKeepIndent: true},
+			{name: "list-all-play_name-table_80", input: "list-all", isTable: true, playFilter: processor.PlayFilter{Title: "WEB"}},
+			{name: "run", input: "run", formatIn: processor.FormatRun},
+			{name: "run-indent", input: "run", formatIn: processor.FormatRun, isIndent: true},
+			{name: "run-table_80", input: "run", formatIn: processor.FormatRun, isTable: true},
+			{name: "run-table_120", input: "run", formatIn: processor.FormatRun, isTable: true, width: 120},
+			{name: "run-play_number", input: "run", formatIn: processor.FormatRun, playFilter: processor.PlayFilter{Number: 2}},
+			{name: "run-json", input: "run-json", formatIn: processor.FormatJson},
+			{name: "run-color", input: "run-color"},
+			{name: "run-color-keep_colors", input: "run-color", isKeepColors: true},
+			{name: "run-json-table_80", input: "run-json", formatIn: processor.FormatJson, isTable: true},
+			{name: "run-format_json", input: "run", format: FormatJson},
+			{name: "run-dossier_80", input: "run", format: FormatDossier},
+			{name: "run-json-format_json", input: "run-json", format: FormatJson},
+			{name: "list-control", input: "list-control"},
+			{name: "list-control-table_80-caret", input: "list-control", isTable: true, sanitize: cmn.SanitizeCaret},
+			{name: "list-tabs", input: "list-tabs"},
+			{name: "list-tabs-table_80-tab_stop_4", input: "list-tabs", isTable: true, tabStop: 4},
+			{name: "list-graphemes-mono-table_60", input: "list-graphemes", isTable: true, isMono: true, width: 60},
+			{name: "list-graphemes-chop_40", input: "list-graphemes", isChop: true, width: 40},
+			{name: "runes-wrap_80", input: "list-tasks-1", isWrap: true, width: 80},
+			{name: "runes-wrap_names_80-indent", input: "list-tasks-1", isWrapNames: true, isIndent: true, width: 80},
+			{name: "run-wrap_60", input: "run", isWrap: true, width: 60},
+			{name: "runes-table_60-wrap", input: "list-tasks-1", isTable: true, isWrap: true, width: 60},
+			{name: "run-table_60-wrap", input: "run", isTable: true, isWrap: true, width: 60},
+			{name: "list-all-dossier_40-wrap", input: "list-all", format: FormatDossier, isWrap: true, width: 40},
+			{name: "wide_ambiguous-table_80-dos", input: "list-tasks-1", isTable: true, isDos: true, isWideAmbig: true},
+			{name: "list-all-table_80-columns", input: "list-all", isTable: true, columns: "number,play,index,name,tag-count,line"},
+			{name: "list-all-dossier_60-columns", input: "list-all", format: FormatDossier, columns: "index,tags,name", width: 60},
+			{name: "list-all-table_80-keep_empty_columns", input: "list-all", isTable: true, isKeepEmpty: true},
+			{name: "list-all-per_play_widths", input: "list-all", isPerPlay: true},
+			{name: "list-all-table_80-per_play_widths", input: "list-all", isTable: true, isPerPlay: true},
+			{name: "list-all-table_80-markdown", input: "list-all", isTable: true, box: cmn.BoxStyleMarkdown},
+			{name: "list-all-dossier_80-rounded", input: "list-all", format: FormatDossier, box: cmn.BoxStyleRounded},
+			{name: "list-all-dossier_80-markdown", input: "list-all", format: FormatDossier, box: cmn.BoxStyleMarkdown},
+			{name: "run-dossier_80-markdown", input: "run", format: FormatDossier, box: cmn.BoxStyleMarkdown},
+			{name: "runes-table_80-borderless", input: "list-tasks-1", isTable: true, box: cmn.BoxStyleBorderless},
+			{name: "list-all-table_80-custom_box", input: "list-all", isTable: true, box: "stars", configPath: "testdata/config.json"},
+			{name: "list-all-color", input: "list-all", color: ColorAlways},
+			{name: "list-all-table_80-color", input: "list-all", isTable: true, color: ColorAlways},
+			{name: "list-all-dossier_80-color_light", input: "list-all", format: FormatDossier, color: ColorAlways, theme: printer.ThemeLight},
+			{name: "list-all-table_80-color_custom", input: "list-all", isTable: true, color: ColorAlways, theme: "calm", configPath: "testdata/config.json"},
+		}
+
+		for _, ti := range tests {
+
+			file := "testdata/out-" + ti.name + ".txt"
+
+			t.Run(ti.name, func(t *testing.T) {
+				c := &Config{
+					TermWidth:          DefaultTermWidth,
+					Format:             ti.format,
+					FormatIn:           ti.formatIn,
+					IsTable:            ti.isTable,
+					IsMono:             ti.isMono,
+					IsChop:             ti.isChop,
+					IsWideAmbiguous:    ti.isWideAmbig,
+					IsWrap:             ti.isWrap,
+					IsWrapNames:        ti.isWrapNames,
+					IsDos:              ti.isDos,
+					IsIndent:           ti.isIndent,
+					IsKeepColors:       ti.isKeepColors,
+					IsAlignPlays:       ti.isAlignPlays,
+					IsKeepIndent:       ti.isKeepIndent,
+					PlayFilter:         ti.playFilter,
+					Sanitize:           ti.sanitize,
+					TabStop:            ti.tabStop,
+					Columns:            ti.columns,
+					IsKeepEmptyColumns: ti.isKeepEmpty,
+					IsPerPlayWidths:    ti.isPerPlay,
+					Box:                ti.box,
+					ConfigPath:         ti.configPath,
+					Color:              ti.color,
+					Theme:              ti.theme,
+					Out:                &out,
+					OutErr:             os.Stderr,
+					Filepath:           "testdata/" + ti.input + ".txt",
+				}
+
+				c.Init(fnTermSize(80, 0, nil))
+
+				if ti.width != 0 {
+					c.TermWidth = ti.width
+				}
+				Run(c)
+
+				want, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := out.String()
+
+				tst.DiffError(t, string(want), got)
+
+				out.Reset()
+			})
+		}
+	})
+
 	t.Run("stats", func(t *testing.T) {
 		var out cmn.LineBuilder
 		// var outErr cmn.LineBuilder
@@ -186,6 +490,8 @@ func TestRun(t *testing.T) {
 			isStats bool
 			// isChop   bool
 			// isIndent bool
+			input    string
+			sanitize string
 		}
 
 		tests := []testItem{
@@ -193,6 +499,8 @@ func TestRun(t *testing.T) {
 			{name: "runes-dos", isStats: true, isDos: true},
 			{name: "mono-ascii", isStats: true, isMono: true},
 			{name: "mono-dos", isStats: true, isMono: true, isDos: true},
+			{name: "run-auto", isStats: true, input: "run"},
+			{name: "list-control-picture", isStats: true, input: "list-control", sanitize: cmn.SanitizePicture},
 		}
 
 		for _, ti := range tests {
@@ -206,11 +514,16 @@ func TestRun(t *testing.T) {
 					IsMono:   ti.isMono,
 					IsDos:    ti.isDos,
 					IsStats:  ti.isStats,
+					Sanitize: ti.sanitize,
 					Out:      &out,
 					OutErr:   os.Stderr,
 					Filepath: "testdata/list-tasks-stats.txt",
 				}
 
+				if ti.input != "" {
+					c.Filepath = "testdata/" + ti.input + ".txt"
+				}
+
 				c.Init(fnTermSize(80, 0, nil))
 				Run(c)
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/keewek/ansible-pretty-print/src/cmn"
 	"github.com/keewek/ansible-pretty-print/src/cmn/flags"
@@ -21,27 +22,71 @@ import (
 // === start: Flags ===
 
 const (
-	kFlagIsChop    = "chop"
-	kFlagIsDos     = "dos"
-	kFlagIsIndent  = "indent"
-	kFlagIsMono    = "mono"
-	kFlagIsStats   = "stats"
-	kFlagIsStdin   = "stdin"
-	kFlagIsTable   = "table"
-	kFlagIsVersion = "version"
-	kFlagWidth     = "width"
+	kFlagBox                = "box"
+	kFlagColor              = "color"
+	kFlagColumnFit          = "column-fit"
+	kFlagConfigPath         = "config"
+	kFlagColumns            = "columns"
+	kFlagFormat             = "format"
+	kFlagFormatIn           = "format-in"
+	kFlagIsAlignPlays       = "align-plays"
+	kFlagIsChop             = "chop"
+	kFlagIsDos              = "dos"
+	kFlagIsIndent           = "indent"
+	kFlagIsKeepColors       = "keep-colors"
+	kFlagIsKeepEmptyColumns = "keep-empty-columns"
+	kFlagIsKeepIndent       = "keep-indent"
+	kFlagIsLenient          = "lenient"
+	kFlagIsMono             = "mono"
+	kFlagIsPerPlayWidths    = "per-play-widths"
+	kFlagIsStats            = "stats"
+	kFlagIsStdin            = "stdin"
+	kFlagIsTable            = "table"
+	kFlagIsVersion          = "version"
+	kFlagIsWideAmbiguous    = "wide-ambiguous"
+	kFlagIsWrap             = "wrap"
+	kFlagIsWrapNames        = "wrap-names"
+	kFlagPlayHosts          = "play-hosts"
+	kFlagPlayName           = "play-name"
+	kFlagPlayNumber         = "play-number"
+	kFlagSanitize           = "sanitize"
+	kFlagTabStop            = "tab-stop"
+	kFlagTheme              = "theme"
+	kFlagWidth              = "width"
 )
 
 var (
-	flagIsChop    = flag.Bool(kFlagIsChop, false, "chop long lines")
-	flagIsDos     = flag.Bool(kFlagIsDos, false, "DOS box-drawing characters")
-	flagIsIndent  = flag.Bool(kFlagIsIndent, false, "indent block/role")
-	flagIsMono    = flag.Bool(kFlagIsMono, false, "calculate string width as monospace width")
-	flagIsStats   = flag.Bool(kFlagIsStats, false, "print stats")
-	flagIsStdin   = flag.Bool(kFlagIsStdin, false, "read standard input")
-	flagIsTable   = flag.Bool(kFlagIsTable, false, "table output")
-	flagIsVersion = flag.Bool(kFlagIsVersion, false, "output version information")
-	flagWidth     = flag.Int(kFlagWidth, 0, "custom line width")
+	flagBox                = flag.String(kFlagBox, "", "box-drawing style: ascii, dos, rounded, double, heavy, dashed, markdown, borderless or one defined in --config file; ascii unless --dos")
+	flagColor              = flag.String(kFlagColor, ColorAuto, "color output: auto (when printing to a terminal and NO_COLOR is unset), always or never")
+	flagColumnFit          = flag.String(kFlagColumnFit, "", "fit table columns by comma-separated column=weight[:min[-max]], e.g. 'block=3,name=2:10,tags=1:8-40'")
+	flagColumns            = flag.String(kFlagColumns, strings.Join(printer.DefaultTaskColumns(), ","), "table columns in order, comma-separated: block, name, tags, play, index (within play), number (overall), tag-count or line (of the input)")
+	flagConfigPath         = flag.String(kFlagConfigPath, "", "JSON config file defining custom box-drawing styles and color themes")
+	flagFormat             = flag.String(kFlagFormat, FormatColumn, "output format: column, table, dossier or json")
+	flagFormatIn           = flag.String(kFlagFormatIn, processor.FormatAuto, "input format: auto, list (--list-* output), run (playbook run output) or json (json callback output)")
+	flagIsAlignPlays       = flag.Bool(kFlagIsAlignPlays, false, "align play number, host pattern and name in columns")
+	flagIsChop             = flag.Bool(kFlagIsChop, false, "chop long lines")
+	flagIsDos              = flag.Bool(kFlagIsDos, false, "DOS box-drawing characters")
+	flagIsIndent           = flag.Bool(kFlagIsIndent, false, "indent block/role")
+	flagIsKeepColors       = flag.Bool(kFlagIsKeepColors, false, "keep colors of the input in lines printed as is")
+	flagIsKeepEmptyColumns = flag.Bool(kFlagIsKeepEmptyColumns, false, "keep table columns that are empty for every task of a play, e.g. Block")
+	flagIsKeepIndent       = flag.Bool(kFlagIsKeepIndent, false, "keep indentation of the input")
+	flagIsLenient          = flag.Bool(kFlagIsLenient, false, "print malformed lines as is with a warning instead of failing")
+	flagIsMono             = flag.Bool(kFlagIsMono, false, "calculate string width as monospace width")
+	flagIsPerPlayWidths    = flag.Bool(kFlagIsPerPlayWidths, false, "size columns of every play by its own content rather than by the whole input")
+	flagIsStats            = flag.Bool(kFlagIsStats, false, "print stats")
+	flagIsStdin            = flag.Bool(kFlagIsStdin, false, "read standard input")
+	flagIsTable            = flag.Bool(kFlagIsTable, false, "table output")
+	flagIsVersion          = flag.Bool(kFlagIsVersion, false, "output version information")
+	flagIsWideAmbiguous    = flag.Bool(kFlagIsWideAmbiguous, false, "calculate string width as monospace width with East Asian ambiguous-width characters wide, as in CJK locales")
+	flagIsWrap             = flag.Bool(kFlagIsWrap, false, "wrap tags and results onto continuation lines, table cells onto extra lines of the row")
+	flagIsWrapNames        = flag.Bool(kFlagIsWrapNames, false, "wrap long names too, implies --wrap")
+	flagPlayHosts          = flag.String(kFlagPlayHosts, "", "show only plays whose host pattern matches a glob, e.g. 'web*'")
+	flagPlayName           = flag.String(kFlagPlayName, "", "show only plays whose name contains a substring (case-insensitive)")
+	flagPlayNumber         = flag.Int(kFlagPlayNumber, 0, "show only a play with the given number")
+	flagSanitize           = flag.String(kFlagSanitize, cmn.SanitizeEscape, "render control characters as: escape, caret or picture")
+	flagTabStop            = flag.Int(kFlagTabStop, cmn.DefaultTabStop, "expand tabs of the input to multiples of the given width")
+	flagTheme              = flag.String(kFlagTheme, printer.ThemeDark, "color theme: dark, light or one defined in --config file")
+	flagWidth              = flag.Int(kFlagWidth, 0, "custom line width")
 )
 
 // === end: Flags ===
@@ -50,27 +95,67 @@ const (
 	DefaultTermWidth = 80
 )
 
+const (
+	FormatColumn  = "column"
+	FormatTable   = "table"
+	FormatJson    = "json"
+	FormatDossier = "dossier"
+)
+
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
 type Printer interface {
 	Print(data *processor.Result)
 	PrintTo(output io.Writer, data *processor.Result)
 }
 
+// ErrPrinter is a Printer that reports a failed PrintTo, e.g. printer.JsonPrinter
+type ErrPrinter interface {
+	Err() error
+}
+
 type TermSizeFunc func() (cols int, lines int, err error)
 
 type Config struct {
-	Filepath  string
-	IsChop    bool
-	IsDos     bool
-	IsIndent  bool
-	IsMono    bool
-	IsStats   bool
-	IsStdin   bool
-	IsTable   bool
-	IsVersion bool
-	TermWidth int
-	Widther   cmn.Widther
-	Out       io.Writer
-	OutErr    io.Writer
+	Box                string
+	Boxes              map[string]cmn.BoxChars // Custom box-drawing styles of the config file
+	Color              string
+	ColumnFit          string
+	Columns            string
+	ConfigPath         string
+	Filepath           string
+	Format             string
+	FormatIn           string
+	IsAlignPlays       bool
+	IsChop             bool
+	IsDos              bool
+	IsIndent           bool
+	IsKeepColors       bool
+	IsKeepEmptyColumns bool
+	IsKeepIndent       bool
+	IsLenient          bool
+	IsMono             bool
+	IsPerPlayWidths    bool
+	IsStats            bool
+	IsStdin            bool
+	IsTable            bool
+	IsVersion          bool
+	IsWideAmbiguous    bool
+	IsWrap             bool
+	IsWrapNames        bool
+	PlayFilter         processor.PlayFilter
+	Sanitize           string
+	TabStop            int
+	TermWidth          int
+	Theme              string
+	Themes             map[string]printer.Theme // Custom color themes of the config file
+	Widther            cmn.Widther
+	Out                io.Writer
+	OutErr             io.Writer
 }
 
 // func isTerminal() bool {
@@ -83,6 +168,12 @@ type Config struct {
 // 	return false
 // }
 
+func isTerminal(output io.Writer) bool {
+	file, ok := output.(*os.File)
+
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
 func termSize() (cols int, lines int, err error) {
 	cols, lines, err = term.GetSize(int(os.Stdout.Fd()))
 
@@ -105,13 +196,19 @@ func DefaultConfig() *Config {
 }
 
 func (c *Config) Init(fnTermSize TermSizeFunc) {
-	if c.IsMono {
+	if c.Format == FormatTable {
+		c.IsTable = true
+	}
+
+	if c.IsWideAmbiguous {
+		c.Widther = cmn.AmbiguousWideWidther{}
+	} else if c.IsMono {
 		c.Widther = cmn.MonospaceWidther{}
 	} else {
 		c.Widther = cmn.RunesWidther{}
 	}
 
-	if (c.IsChop || c.IsTable) && !flags.IsSet("width") {
+	if (c.IsChop || c.IsWrap || c.IsWrapNames || c.IsTable || c.Format == FormatDossier) && !flags.IsSet("width") {
 		// Try determine terminal width
 		cols, _, err := fnTermSize()
 		if err != nil {
@@ -129,24 +226,263 @@ func (c *Config) Init(fnTermSize TermSizeFunc) {
 	}
 }
 
-func (c *Config) AcquireBoxChars() cmn.BoxChars {
+func (c *Config) ValidateFormat() error {
+	switch c.Format {
+	case "", FormatColumn, FormatTable, FormatJson, FormatDossier:
+		return nil
+	}
+
+	return fmt.Errorf("Config.ValidateFormat: unknown output format %q", c.Format)
+}
+
+// AcquireFormatIn returns input format to parse with, detected one unless set explicitly
+func (c *Config) AcquireFormatIn() string {
+	if c.FormatIn == "" {
+		return processor.FormatAuto
+	}
+
+	return c.FormatIn
+}
+
+func (c *Config) ValidateFormatIn() error {
+	switch c.FormatIn {
+	case "", processor.FormatAuto, processor.FormatList, processor.FormatRun, processor.FormatJson:
+		return nil
+	}
+
+	return fmt.Errorf("Config.ValidateFormatIn: unknown input format %q", c.FormatIn)
+}
+
+// AcquireSanitize returns style of control character rendering, escapes unless set explicitly
+func (c *Config) AcquireSanitize() string {
+	if c.Sanitize == "" {
+		return cmn.SanitizeEscape
+	}
+
+	return c.Sanitize
+}
+
+func (c *Config) ValidateSanitize() error {
+	switch c.Sanitize {
+	case "", cmn.SanitizeEscape, cmn.SanitizeCaret, cmn.SanitizePicture:
+		return nil
+	}
+
+	return fmt.Errorf("Config.ValidateSanitize: unknown sanitize style %q", c.Sanitize)
+}
+
+// AcquireTabStop returns tab stop to expand tabs of the input to, the default one unless set explicitly
+func (c *Config) AcquireTabStop() int {
+	if c.TabStop == 0 {
+		return cmn.DefaultTabStop
+	}
+
+	return c.TabStop
+}
+
+func (c *Config) ValidateTabStop() error {
+	if c.TabStop < 0 {
+		return fmt.Errorf("Config.ValidateTabStop: negative tab stop %d", c.TabStop)
+	}
+
+	return nil
+}
+
+// AcquireColumnFit returns fitting of table columns, the default one for columns not set explicitly
+func (c *Config) AcquireColumnFit() map[string]printer.TableColumn {
+	columns, err := printer.ParseTableColumns(c.ColumnFit)
+	if err != nil {
+		return printer.DefaultTableColumns()
+	}
+
+	return columns
+}
+
+func (c *Config) ValidateColumnFit() error {
+	if _, err := printer.ParseTableColumns(c.ColumnFit); err != nil {
+		return fmt.Errorf("Config.ValidateColumnFit: %w", err)
+	}
+
+	return nil
+}
+
+// AcquireColumns returns columns of the task table, the default ones unless set explicitly
+func (c *Config) AcquireColumns() []string {
+	columns, err := printer.ParseTaskColumns(c.Columns)
+	if err != nil {
+		return printer.DefaultTaskColumns()
+	}
+
+	return columns
+}
+
+func (c *Config) ValidateColumns() error {
+	if _, err := printer.ParseTaskColumns(c.Columns); err != nil {
+		return fmt.Errorf("Config.ValidateColumns: %w", err)
+	}
+
+	return nil
+}
+
+// LoadConfigFile reads settings of the config file, if any
+func (c *Config) LoadConfigFile() error {
+	if c.ConfigPath == "" {
+		return nil
+	}
+
+	cf, err := ReadConfigFile(c.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("Config.LoadConfigFile: %w", err)
+	}
+
+	boxes, err := cf.BoxChars(c.Widther)
+	if err != nil {
+		return fmt.Errorf("Config.LoadConfigFile: %w", err)
+	}
+
+	c.Boxes = boxes
+
+	themes, err := cf.ColorThemes()
+	if err != nil {
+		return fmt.Errorf("Config.LoadConfigFile: %w", err)
+	}
+
+	c.Themes = themes
+
+	return nil
+}
+
+// AcquireBoxStyle returns box-drawing style, DOS one with --dos and ASCII one unless set explicitly
+func (c *Config) AcquireBoxStyle() string {
+	if c.Box != "" {
+		return c.Box
+	}
+
 	if c.IsDos {
-		return cmn.BoxCharsDos()
+		return cmn.BoxStyleDos
 	}
 
-	return cmn.BoxCharsAscii()
+	return cmn.BoxStyleAscii
+}
+
+// boxChars returns characters of a custom box-drawing style or, if there's no such, of a
+// built-in one
+func (c *Config) boxChars(style string) (cmn.BoxChars, bool) {
+	if box, ok := c.Boxes[style]; ok {
+		return box, true
+	}
+
+	return cmn.BoxCharsByStyle(style)
+}
+
+func (c *Config) ValidateBox() error {
+	if _, ok := c.boxChars(c.AcquireBoxStyle()); !ok {
+		return fmt.Errorf("Config.ValidateBox: unknown box style %q", c.Box)
+	}
+
+	return nil
+}
+
+// AcquireBoxChars returns box-drawing characters that fit into a cell with the configured Widther
+func (c *Config) AcquireBoxChars() cmn.BoxChars {
+	box, ok := c.boxChars(c.AcquireBoxStyle())
+	if !ok {
+		box = cmn.BoxCharsAscii()
+	}
+
+	return cmn.BoxCharsFit(box, c.Widther)
+}
+
+func (c *Config) ValidateColor() error {
+	switch c.Color {
+	case "", ColorAuto, ColorAlways, ColorNever:
+		return nil
+	}
+
+	return fmt.Errorf("Config.ValidateColor: unknown color mode %q", c.Color)
+}
+
+// AcquireIsColor reports whether to color output. In auto mode, the default one, output is
+// colored when printed to a terminal unless NO_COLOR is set, see https://no-color.org
+func (c *Config) AcquireIsColor() bool {
+	switch c.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	return os.Getenv("NO_COLOR") == "" && isTerminal(c.Out)
+}
+
+// themeByName returns colors of a custom theme or, if there's no such, of a built-in one
+func (c *Config) themeByName(name string) (printer.Theme, bool) {
+	if theme, ok := c.Themes[name]; ok {
+		return theme, true
+	}
+
+	return printer.ThemeByName(name)
+}
+
+// acquireThemeName returns name of the color theme, the dark one unless set explicitly
+func (c *Config) acquireThemeName() string {
+	if c.Theme == "" {
+		return printer.ThemeDark
+	}
+
+	return c.Theme
+}
+
+func (c *Config) ValidateTheme() error {
+	if _, ok := c.themeByName(c.acquireThemeName()); !ok {
+		return fmt.Errorf("Config.ValidateTheme: unknown theme %q", c.Theme)
+	}
+
+	return nil
+}
+
+// AcquireTheme returns colors of output, monochrome ones unless output is colored
+func (c *Config) AcquireTheme() printer.Theme {
+	if c.Format == FormatJson || !c.AcquireIsColor() {
+		return printer.Theme{}
+	}
+
+	theme, _ := c.themeByName(c.acquireThemeName())
+
+	return theme
 }
 
 func (c *Config) AcquirePrinter() Printer {
 	var p Printer
 
-	if c.IsTable {
+	if c.Format == FormatJson {
+		p = printer.NewJsonPrinter()
+	} else if c.Format == FormatDossier {
+		dp := printer.NewDossierPrinter()
+		dp.SetWidther(c.Widther)
+		dp.SetMaxLineWidth(c.TermWidth)
+		dp.SetIsWrapCells(c.IsWrap || c.IsWrapNames)
+		dp.SetColumnFit(c.AcquireColumnFit())
+		dp.SetTaskColumns(c.AcquireColumns())
+		dp.SetIsKeepEmptyColumns(c.IsKeepEmptyColumns)
+		dp.SetIsPerPlayWidths(c.IsPerPlayWidths)
+		dp.SetBoxChars(c.AcquireBoxChars())
+		dp.SetTheme(c.AcquireTheme())
+
+		p = dp
+	} else if c.IsTable {
 		tp := printer.NewTablePrinter()
 		tp.SetWidther(c.Widther)
 		tp.SetMaxLineWidth(c.TermWidth)
-		if c.IsDos {
-			tp.SetBoxChars(cmn.BoxCharsDos())
-		}
+		tp.SetIsAlignPlays(c.IsAlignPlays)
+		tp.SetIsKeepIndent(c.IsKeepIndent)
+		tp.SetIsWrapCells(c.IsWrap || c.IsWrapNames)
+		tp.SetColumnFit(c.AcquireColumnFit())
+		tp.SetTaskColumns(c.AcquireColumns())
+		tp.SetIsKeepEmptyColumns(c.IsKeepEmptyColumns)
+		tp.SetIsPerPlayWidths(c.IsPerPlayWidths)
+		tp.SetBoxChars(c.AcquireBoxChars())
+		tp.SetTheme(c.AcquireTheme())
 
 		p = tp
 	} else {
@@ -154,8 +490,14 @@ func (c *Config) AcquirePrinter() Printer {
 
 		cp.SetWidther(c.Widther)
 		cp.SetIsChopLines(c.IsChop)
+		cp.SetIsWrapLines(c.IsWrap || c.IsWrapNames)
+		cp.SetIsWrapNames(c.IsWrapNames)
 		cp.SetMaxLineWidth(c.TermWidth)
 		cp.SetIsIndentBlock(c.IsIndent)
+		cp.SetIsAlignPlays(c.IsAlignPlays)
+		cp.SetIsKeepIndent(c.IsKeepIndent)
+		cp.SetIsPerPlayWidths(c.IsPerPlayWidths)
+		cp.SetTheme(c.AcquireTheme())
 
 		p = cp
 	}
@@ -197,6 +539,38 @@ func (c *Config) ApplyFlags() {
 		c.Filepath = fp
 	}
 
+	if flags.IsSet(kFlagBox) {
+		c.Box = *flagBox
+	}
+
+	if flags.IsSet(kFlagColor) {
+		c.Color = *flagColor
+	}
+
+	if flags.IsSet(kFlagColumnFit) {
+		c.ColumnFit = *flagColumnFit
+	}
+
+	if flags.IsSet(kFlagColumns) {
+		c.Columns = *flagColumns
+	}
+
+	if flags.IsSet(kFlagConfigPath) {
+		c.ConfigPath = *flagConfigPath
+	}
+
+	if flags.IsSet(kFlagFormat) {
+		c.Format = *flagFormat
+	}
+
+	if flags.IsSet(kFlagFormatIn) {
+		c.FormatIn = *flagFormatIn
+	}
+
+	if flags.IsSet(kFlagIsAlignPlays) {
+		c.IsAlignPlays = *flagIsAlignPlays
+	}
+
 	if flags.IsSet(kFlagIsChop) {
 		c.IsChop = *flagIsChop
 	}
@@ -209,10 +583,30 @@ func (c *Config) ApplyFlags() {
 		c.IsIndent = *flagIsIndent
 	}
 
+	if flags.IsSet(kFlagIsKeepColors) {
+		c.IsKeepColors = *flagIsKeepColors
+	}
+
+	if flags.IsSet(kFlagIsKeepEmptyColumns) {
+		c.IsKeepEmptyColumns = *flagIsKeepEmptyColumns
+	}
+
+	if flags.IsSet(kFlagIsKeepIndent) {
+		c.IsKeepIndent = *flagIsKeepIndent
+	}
+
+	if flags.IsSet(kFlagIsLenient) {
+		c.IsLenient = *flagIsLenient
+	}
+
 	if flags.IsSet(kFlagIsMono) {
 		c.IsMono = *flagIsMono
 	}
 
+	if flags.IsSet(kFlagIsPerPlayWidths) {
+		c.IsPerPlayWidths = *flagIsPerPlayWidths
+	}
+
 	if flags.IsSet(kFlagIsStats) {
 		c.IsStats = *flagIsStats
 	}
@@ -229,6 +623,42 @@ func (c *Config) ApplyFlags() {
 		c.IsVersion = *flagIsVersion
 	}
 
+	if flags.IsSet(kFlagIsWideAmbiguous) {
+		c.IsWideAmbiguous = *flagIsWideAmbiguous
+	}
+
+	if flags.IsSet(kFlagIsWrap) {
+		c.IsWrap = *flagIsWrap
+	}
+
+	if flags.IsSet(kFlagIsWrapNames) {
+		c.IsWrapNames = *flagIsWrapNames
+	}
+
+	if flags.IsSet(kFlagPlayHosts) {
+		c.PlayFilter.HostPattern = *flagPlayHosts
+	}
+
+	if flags.IsSet(kFlagPlayName) {
+		c.PlayFilter.Title = *flagPlayName
+	}
+
+	if flags.IsSet(kFlagPlayNumber) {
+		c.PlayFilter.Number = *flagPlayNumber
+	}
+
+	if flags.IsSet(kFlagSanitize) {
+		c.Sanitize = *flagSanitize
+	}
+
+	if flags.IsSet(kFlagTabStop) {
+		c.TabStop = *flagTabStop
+	}
+
+	if flags.IsSet(kFlagTheme) {
+		c.Theme = *flagTheme
+	}
+
 	if flags.IsSet(kFlagWidth) {
 		c.TermWidth = *flagWidth
 	}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/printer"
+)
+
+// ConfigFile holds settings that don't fit on the command line, read from a JSON file, e.g.
+//
+//	{
+//	  "boxes": {
+//	    "stars": { "base": "ascii", "hor": "*", "ver": "*", "cross": "*" }
+//	  },
+//	  "themes": {
+//	    "calm": { "base": "dark", "play": "1;35", "border": "" }
+//	  }
+//	}
+type ConfigFile struct {
+	Boxes  map[string]*jsonBoxChars `json:"boxes"`  // Custom box-drawing styles by name, see --box
+	Themes map[string]*jsonTheme    `json:"themes"` // Custom color themes by name, see --theme
+}
+
+// jsonBoxChars is a custom box-drawing style. Characters not set are taken from the base style.
+type jsonBoxChars struct {
+	Base     string  `json:"base"` // Box-drawing style to start from, ascii by default
+	CornerTL *string `json:"corner_tl"`
+	CornerTR *string `json:"corner_tr"`
+	CornerBL *string `json:"corner_bl"`
+	CornerBR *string `json:"corner_br"`
+	Left     *string `json:"left"`
+	Right    *string `json:"right"`
+	Top      *string `json:"top"`
+	Bottom   *string `json:"bottom"`
+	Cross    *string `json:"cross"`
+	Hor      *string `json:"hor"`
+	Ver      *string `json:"ver"`
+	IsOpen   *bool   `json:"open"` // Top and bottom borders aren't drawn
+}
+
+// boxChars returns characters of the style, characters not set taken from the base style.
+// Characters set must be a single cell wide as measured by w; only built-in styles blank some.
+func (jb *jsonBoxChars) boxChars(w cmn.Widther) (cmn.BoxChars, error) {
+	base := jb.Base
+	if base == "" {
+		base = cmn.BoxStyleAscii
+	}
+
+	box, ok := cmn.BoxCharsByStyle(base)
+	if !ok {
+		return box, fmt.Errorf("unknown base box style %q", jb.Base)
+	}
+
+	fnSet := func(dst *string, src *string, name string) error {
+		if src == nil {
+			return nil
+		}
+
+		if w.Width(*src) != 1 {
+			return fmt.Errorf("%s: %q isn't a single-cell character", name, *src)
+		}
+
+		*dst = *src
+
+		return nil
+	}
+
+	for _, item := range []struct {
+		dst  *string
+		src  *string
+		name string
+	}{
+		{&box.CornerTL, jb.CornerTL, "corner_tl"},
+		{&box.CornerTR, jb.CornerTR, "corner_tr"},
+		{&box.CornerBL, jb.CornerBL, "corner_bl"},
+		{&box.CornerBR, jb.CornerBR, "corner_br"},
+		{&box.Left, jb.Left, "left"},
+		{&box.Right, jb.Right, "right"},
+		{&box.Top, jb.Top, "top"},
+		{&box.Bottom, jb.Bottom, "bottom"},
+		{&box.Cross, jb.Cross, "cross"},
+		{&box.Hor, jb.Hor, "hor"},
+		{&box.Ver, jb.Ver, "ver"},
+	} {
+		if err := fnSet(item.dst, item.src, item.name); err != nil {
+			return box, err
+		}
+	}
+
+	if jb.IsOpen != nil {
+		box.IsOpen = *jb.IsOpen
+	}
+
+	return box, nil
+}
+
+// jsonTheme is a custom color theme of SGR parameters. Styles not set are taken from the base theme.
+type jsonTheme struct {
+	Base   string  `json:"base"` // Theme to start from, dark by default
+	Play   *string `json:"play"`
+	Block  *string `json:"block"`
+	Name   *string `json:"name"`
+	Tags   *string `json:"tags"`
+	Border *string `json:"border"`
+}
+
+// theme returns styles of the theme, styles not set taken from the base theme
+func (jt *jsonTheme) theme() (printer.Theme, error) {
+	base := jt.Base
+	if base == "" {
+		base = printer.ThemeDark
+	}
+
+	theme, ok := printer.ThemeByName(base)
+	if !ok {
+		return theme, fmt.Errorf("unknown base theme %q", jt.Base)
+	}
+
+	fnSet := func(dst *string, src *string, name string) error {
+		if src == nil {
+			return nil
+		}
+
+		if !cmn.IsSgrParams(*src) {
+			return fmt.Errorf("%s: malformed SGR parameters %q", name, *src)
+		}
+
+		*dst = *src
+
+		return nil
+	}
+
+	for _, item := range []struct {
+		dst  *string
+		src  *string
+		name string
+	}{
+		{&theme.Play, jt.Play, "play"},
+		{&theme.Block, jt.Block, "block"},
+		{&theme.Name, jt.Name, "name"},
+		{&theme.Tags, jt.Tags, "tags"},
+		{&theme.Border, jt.Border, "border"},
+	} {
+		if err := fnSet(item.dst, item.src, item.name); err != nil {
+			return theme, err
+		}
+	}
+
+	return theme, nil
+}
+
+// ColorThemes returns custom color themes by name
+func (cf *ConfigFile) ColorThemes() (map[string]printer.Theme, error) {
+	themes := make(map[string]printer.Theme, len(cf.Themes))
+
+	for name, jt := range cf.Themes {
+		if jt == nil {
+			return nil, fmt.Errorf("ConfigFile.ColorThemes: theme %q: no styles", name)
+		}
+
+		theme, err := jt.theme()
+		if err != nil {
+			return nil, fmt.Errorf("ConfigFile.ColorThemes: theme %q: %w", name, err)
+		}
+
+		themes[name] = theme
+	}
+
+	return themes, nil
+}
+
+// BoxChars returns custom box-drawing styles by name, characters measured by w
+func (cf *ConfigFile) BoxChars(w cmn.Widther) (map[string]cmn.BoxChars, error) {
+	boxes := make(map[string]cmn.BoxChars, len(cf.Boxes))
+
+	for name, jb := range cf.Boxes {
+		if jb == nil {
+			return nil, fmt.Errorf("ConfigFile.BoxChars: box %q: no characters", name)
+		}
+
+		box, err := jb.boxChars(w)
+		if err != nil {
+			return nil, fmt.Errorf("ConfigFile.BoxChars: box %q: %w", name, err)
+		}
+
+		boxes[name] = box
+	}
+
+	return boxes, nil
+}
+
+// ReadConfigFile reads a JSON config file, unknown keys are an error
+func ReadConfigFile(path string) (*ConfigFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ReadConfigFile: %w", err)
+	}
+
+	defer file.Close()
+
+	var cf ConfigFile
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&cf); err != nil {
+		return nil, fmt.Errorf("ReadConfigFile: %s: %w", path, err)
+	}
+
+	return &cf, nil
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/printer"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	fnWrite := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.json")
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	t.Run("Boxes", func(t *testing.T) {
+		cf, err := ReadConfigFile(fnWrite(t, `{"boxes": {"open": {"base": "dos", "ver": " ", "open": true}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := cf.BoxChars(cmn.RunesWidther{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		open := cmn.BoxCharsDos()
+		open.Ver = " "
+		open.IsOpen = true
+
+		want := map[string]cmn.BoxChars{"open": open}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Themes", func(t *testing.T) {
+		cf, err := ReadConfigFile(fnWrite(t, `{"themes": {"plain": {"base": "light", "name": "", "tags": "4"}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := cf.ColorThemes()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		plain := printer.ThemeColorsLight()
+		plain.Name = ""
+		plain.Tags = "4"
+
+		want := map[string]printer.Theme{"plain": plain}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Fails on malformed SGR parameters", func(t *testing.T) {
+		cf, err := ReadConfigFile(fnWrite(t, `{"themes": {"x": {"play": "red"}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = cf.ColorThemes()
+
+		if diff := cmp.Diff(`ConfigFile.ColorThemes: theme "x": play: malformed SGR parameters "red"`, err.Error()); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Fails on unknown keys", func(t *testing.T) {
+		if _, err := ReadConfigFile(fnWrite(t, `{"boxes": {"x": {"corner": "+"}}}`)); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("Fails on unknown base style", func(t *testing.T) {
+		cf, err := ReadConfigFile(fnWrite(t, `{"boxes": {"x": {"base": "fancy"}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = cf.BoxChars(cmn.RunesWidther{})
+
+		if diff := cmp.Diff(`ConfigFile.BoxChars: box "x": unknown base box style "fancy"`, err.Error()); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Fails on characters not a single cell wide", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			content string
+			widther cmn.Widther
+			want    string
+		}{
+			{"Empty", `{"boxes": {"x": {"hor": ""}}}`, cmn.RunesWidther{}, `ConfigFile.BoxChars: box "x": hor: "" isn't a single-cell character`},
+			{"Two characters", `{"boxes": {"x": {"ver": "||"}}}`, cmn.RunesWidther{}, `ConfigFile.BoxChars: box "x": ver: "||" isn't a single-cell character`},
+			{"Ambiguous wide", `{"boxes": {"x": {"cross": "┼"}}}`, cmn.AmbiguousWideWidther{}, `ConfigFile.BoxChars: box "x": cross: "┼" isn't a single-cell character`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cf, err := ReadConfigFile(fnWrite(t, tt.content))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				_, err = cf.BoxChars(tt.widther)
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				if diff := cmp.Diff(tt.want, err.Error()); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Built-in blanks are kept", func(t *testing.T) {
+		cf, err := ReadConfigFile(fnWrite(t, `{"boxes": {"md": {"base": "markdown", "ver": ":"}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := cf.BoxChars(cmn.RunesWidther{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		md := cmn.BoxCharsMarkdown()
+		md.Ver = ":"
+
+		if diff := cmp.Diff(map[string]cmn.BoxChars{"md": md}, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Fails on a box of no characters", func(t *testing.T) {
+		cf, err := ReadConfigFile(fnWrite(t, `{"boxes": {"x": null}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := cf.BoxChars(cmn.RunesWidther{}); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
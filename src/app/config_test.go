@@ -9,11 +9,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keewek/ansible-pretty-print/src/cmn"
 	"github.com/keewek/ansible-pretty-print/src/cmn/flags"
+	"github.com/keewek/ansible-pretty-print/src/printer"
+	"github.com/keewek/ansible-pretty-print/src/processor"
 )
 
 func fileComparer(x, y *os.File) bool {
@@ -63,6 +66,22 @@ func Test_ConfigInit(t *testing.T) {
 
 	})
 
+	t.Run("AmbiguousWideWidther", func(t *testing.T) {
+		c := &Config{
+			IsMono:          true,
+			IsWideAmbiguous: true,
+		}
+
+		c.Init(fnTermSize(0, 0, nil))
+		want := "cmn.AmbiguousWideWidther"
+		got := fmt.Sprintf("%T", c.Widther)
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+	})
+
 	t.Run("RunesWidther", func(t *testing.T) {
 		c := &Config{
 			IsMono: false,
@@ -176,6 +195,222 @@ func Test_ConfigAcquireBoxChars(t *testing.T) {
 			t.Errorf("(-want +got): \n%s", diff)
 		}
 	})
+
+	t.Run("Box style wins over Dos", func(t *testing.T) {
+		c := &Config{
+			Box:   cmn.BoxStyleDouble,
+			IsDos: true,
+		}
+
+		want := cmn.BoxCharsDouble()
+		got := c.AcquireBoxChars()
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		c := &Config{
+			Box:   "stars",
+			Boxes: map[string]cmn.BoxChars{"stars": {Hor: "*", Ver: "*"}},
+		}
+
+		want := cmn.BoxChars{Hor: "*", Ver: "*"}
+		got := c.AcquireBoxChars()
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Dos, ambiguous wide", func(t *testing.T) {
+		c := &Config{
+			IsDos:   true,
+			Widther: cmn.AmbiguousWideWidther{},
+		}
+
+		want := cmn.BoxCharsAscii()
+		got := c.AcquireBoxChars()
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+}
+
+func Test_ConfigValidateBox(t *testing.T) {
+	tests := []struct {
+		c       *Config
+		isValid bool
+	}{
+		{&Config{}, true},
+		{&Config{Box: cmn.BoxStyleMarkdown}, true},
+		{&Config{Box: "stars", Boxes: map[string]cmn.BoxChars{"stars": {}}}, true},
+		{&Config{Box: "stars"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.c.Box, func(t *testing.T) {
+			if diff := cmp.Diff(tt.isValid, tt.c.ValidateBox() == nil); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigLoadConfigFile(t *testing.T) {
+	t.Run("No config file", func(t *testing.T) {
+		c := &Config{}
+
+		if err := c.LoadConfigFile(); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Boxes != nil {
+			t.Errorf("want no boxes, got %v", c.Boxes)
+		}
+	})
+
+	t.Run("Boxes", func(t *testing.T) {
+		c := &Config{ConfigPath: "testdata/config.json", Widther: cmn.RunesWidther{}}
+
+		if err := c.LoadConfigFile(); err != nil {
+			t.Fatal(err)
+		}
+
+		stars := cmn.BoxCharsAscii()
+		stars.Hor = "*"
+		stars.Ver = "*"
+		stars.Cross = "*"
+
+		roundedOpen := cmn.BoxCharsRounded()
+		roundedOpen.IsOpen = true
+
+		want := map[string]cmn.BoxChars{"stars": stars, "rounded-open": roundedOpen}
+
+		if diff := cmp.Diff(want, c.Boxes); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Themes", func(t *testing.T) {
+		c := &Config{ConfigPath: "testdata/config.json", Widther: cmn.RunesWidther{}}
+
+		if err := c.LoadConfigFile(); err != nil {
+			t.Fatal(err)
+		}
+
+		calm := printer.ThemeColorsLight()
+		calm.Play = "1;35"
+		calm.Border = ""
+
+		want := map[string]printer.Theme{"calm": calm}
+
+		if diff := cmp.Diff(want, c.Themes); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Missing config file", func(t *testing.T) {
+		c := &Config{ConfigPath: "testdata/no-such-config.json"}
+
+		if err := c.LoadConfigFile(); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func Test_ConfigValidateColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		isValid bool
+	}{
+		{"", true},
+		{ColorAuto, true},
+		{ColorAlways, true},
+		{ColorNever, true},
+		{"sometimes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			c := &Config{Color: tt.color}
+
+			if diff := cmp.Diff(tt.isValid, c.ValidateColor() == nil); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigAcquireIsColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		noColor string
+		want    bool
+	}{
+		{ColorAlways, "", true},
+		{ColorAlways, "1", true},
+		{ColorNever, "", false},
+		{ColorAuto, "1", false},
+		{ColorAuto, "", false}, // Output isn't a terminal
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s NO_COLOR=%s", tt.color, tt.noColor), func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			c := &Config{Color: tt.color, Out: &strings.Builder{}}
+
+			if diff := cmp.Diff(tt.want, c.AcquireIsColor()); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigValidateTheme(t *testing.T) {
+	tests := []struct {
+		c       *Config
+		isValid bool
+	}{
+		{&Config{}, true},
+		{&Config{Theme: printer.ThemeLight}, true},
+		{&Config{Theme: "calm", Themes: map[string]printer.Theme{"calm": {}}}, true},
+		{&Config{Theme: "calm"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.c.Theme, func(t *testing.T) {
+			if diff := cmp.Diff(tt.isValid, tt.c.ValidateTheme() == nil); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigAcquireTheme(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Config
+		want printer.Theme
+	}{
+		{"Never", &Config{Color: ColorNever}, printer.Theme{}},
+		{"Default", &Config{Color: ColorAlways}, printer.ThemeColorsDark()},
+		{"Light", &Config{Color: ColorAlways, Theme: printer.ThemeLight}, printer.ThemeColorsLight()},
+		{"Custom", &Config{Color: ColorAlways, Theme: "calm", Themes: map[string]printer.Theme{"calm": {Play: "35"}}}, printer.Theme{Play: "35"}},
+		{"JSON", &Config{Color: ColorAlways, Format: FormatJson}, printer.Theme{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.c.AcquireTheme()); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
 }
 
 func Test_ConfigAcquirePrinter(t *testing.T) {
@@ -208,6 +443,167 @@ func Test_ConfigAcquirePrinter(t *testing.T) {
 		}
 
 	})
+
+	t.Run("Format", func(t *testing.T) {
+		tests := []struct {
+			format string
+			want   string
+		}{
+			{"", "*printer.ColumnPrinter"},
+			{FormatColumn, "*printer.ColumnPrinter"},
+			{FormatTable, "*printer.TablePrinter"},
+			{FormatJson, "*printer.JsonPrinter"},
+			{FormatDossier, "*printer.DossierPrinter"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.format, func(t *testing.T) {
+				c := &Config{
+					Format: tt.format,
+				}
+
+				c.Init(func() (int, int, error) { return 80, 0, nil })
+				p := c.AcquirePrinter()
+				got := fmt.Sprintf("%T", p)
+
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+			})
+		}
+	})
+}
+
+func Test_ConfigValidateFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		isErr  bool
+	}{
+		{"", false},
+		{FormatColumn, false},
+		{FormatTable, false},
+		{FormatJson, false},
+		{FormatDossier, false},
+		{"xml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			c := &Config{Format: tt.format}
+
+			err := c.ValidateFormat()
+
+			if diff := cmp.Diff(tt.isErr, err != nil); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigValidateFormatIn(t *testing.T) {
+	tests := []struct {
+		format string
+		isErr  bool
+	}{
+		{"", false},
+		{processor.FormatAuto, false},
+		{processor.FormatList, false},
+		{processor.FormatRun, false},
+		{processor.FormatJson, false},
+		{"yaml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			c := &Config{FormatIn: tt.format}
+
+			err := c.ValidateFormatIn()
+
+			if diff := cmp.Diff(tt.isErr, err != nil); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigAcquireFormatIn(t *testing.T) {
+	want := []string{processor.FormatAuto, processor.FormatRun}
+	got := []string{(&Config{}).AcquireFormatIn(), (&Config{FormatIn: processor.FormatRun}).AcquireFormatIn()}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+}
+
+func Test_ConfigValidateSanitize(t *testing.T) {
+	tests := []struct {
+		style string
+		isErr bool
+	}{
+		{"", false},
+		{cmn.SanitizeEscape, false},
+		{cmn.SanitizeCaret, false},
+		{cmn.SanitizePicture, false},
+		{"hex", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			c := &Config{Sanitize: tt.style}
+
+			err := c.ValidateSanitize()
+
+			if diff := cmp.Diff(tt.isErr, err != nil); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ConfigTabStop(t *testing.T) {
+	want := []int{cmn.DefaultTabStop, 4}
+	got := []int{(&Config{}).AcquireTabStop(), (&Config{TabStop: 4}).AcquireTabStop()}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff(true, (&Config{TabStop: -1}).ValidateTabStop() != nil); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+}
+
+func Test_ConfigColumnFit(t *testing.T) {
+	want := printer.DefaultTableColumns()
+	want[printer.ColumnTags] = printer.TableColumn{Min: 8, Weight: 5}
+
+	if diff := cmp.Diff(want, (&Config{ColumnFit: "tags=5:8"}).AcquireColumnFit()); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff(printer.DefaultTableColumns(), (&Config{}).AcquireColumnFit()); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff(true, (&Config{ColumnFit: "tags"}).ValidateColumnFit() != nil); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+}
+
+func Test_ConfigColumns(t *testing.T) {
+	want := []string{printer.ColumnIndex, printer.ColumnName}
+
+	if diff := cmp.Diff(want, (&Config{Columns: "index,name"}).AcquireColumns()); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff(printer.DefaultTaskColumns(), (&Config{}).AcquireColumns()); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff(true, (&Config{Columns: "name,host"}).ValidateColumns() != nil); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
 }
 
 func Test_ConfigAcquireScanner(t *testing.T) {
@@ -286,29 +682,71 @@ func Test_ConfigAcquireScanner(t *testing.T) {
 }
 
 func Test_ConfigApplyFlags(t *testing.T) {
+	flag.Set(kFlagBox, cmn.BoxStyleRounded)
+	flag.Set(kFlagColor, ColorAlways)
+	flag.Set(kFlagColumnFit, "tags=5")
+	flag.Set(kFlagColumns, "index,name")
+	flag.Set(kFlagConfigPath, "config.json")
+	flag.Set(kFlagFormat, FormatJson)
+	flag.Set(kFlagFormatIn, processor.FormatRun)
+	flag.Set(kFlagIsAlignPlays, "1")
 	flag.Set(kFlagIsChop, "1")
 	flag.Set(kFlagIsDos, "1")
 	flag.Set(kFlagIsIndent, "1")
+	flag.Set(kFlagIsKeepColors, "1")
+	flag.Set(kFlagIsKeepEmptyColumns, "1")
+	flag.Set(kFlagIsPerPlayWidths, "1")
+	flag.Set(kFlagIsKeepIndent, "1")
+	flag.Set(kFlagIsLenient, "1")
 	flag.Set(kFlagIsMono, "1")
 	flag.Set(kFlagIsStats, "1")
 	flag.Set(kFlagIsStdin, "1")
 	flag.Set(kFlagIsTable, "1")
 	flag.Set(kFlagIsVersion, "1")
+	flag.Set(kFlagIsWideAmbiguous, "1")
+	flag.Set(kFlagIsWrap, "1")
+	flag.Set(kFlagIsWrapNames, "1")
+	flag.Set(kFlagPlayHosts, "web*")
+	flag.Set(kFlagPlayName, "deploy")
+	flag.Set(kFlagPlayNumber, "2")
+	flag.Set(kFlagSanitize, cmn.SanitizeCaret)
+	flag.Set(kFlagTabStop, "4")
+	flag.Set(kFlagTheme, printer.ThemeLight)
 	flag.Set(kFlagWidth, "40")
 
 	flags.EnableAll()
 
 	want := &Config{
-		IsChop:    true,
-		IsDos:     true,
-		IsIndent:  true,
-		IsMono:    true,
-		IsStats:   true,
-		IsStdin:   true,
-		IsTable:   true,
-		IsVersion: true,
-		TermWidth: 40,
-		Widther:   nil,
+		Box:                cmn.BoxStyleRounded,
+		Color:              ColorAlways,
+		ColumnFit:          "tags=5",
+		Columns:            "index,name",
+		ConfigPath:         "config.json",
+		Format:             FormatJson,
+		FormatIn:           processor.FormatRun,
+		IsAlignPlays:       true,
+		IsChop:             true,
+		IsDos:              true,
+		IsIndent:           true,
+		IsKeepColors:       true,
+		IsKeepEmptyColumns: true,
+		IsPerPlayWidths:    true,
+		IsKeepIndent:       true,
+		IsLenient:          true,
+		IsMono:             true,
+		IsStats:            true,
+		IsStdin:            true,
+		IsTable:            true,
+		IsVersion:          true,
+		IsWideAmbiguous:    true,
+		IsWrap:             true,
+		IsWrapNames:        true,
+		PlayFilter:         processor.PlayFilter{Number: 2, HostPattern: "web*", Title: "deploy"},
+		Sanitize:           cmn.SanitizeCaret,
+		TabStop:            4,
+		TermWidth:          40,
+		Theme:              printer.ThemeLight,
+		Widther:            nil,
 	}
 
 	got := &Config{}
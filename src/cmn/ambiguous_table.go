@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import "unicode"
+
+// ambiguousWidth holds East Asian Width "A" (ambiguous) code points of Unicode 14.0.0,
+// the version the uniseg package measures widths by
+var ambiguousWidth = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00A1, 0x00A1, 1},
+		{0x00A4, 0x00A4, 1},
+		{0x00A7, 0x00A8, 1},
+		{0x00AA, 0x00AA, 1},
+		{0x00AD, 0x00AE, 1},
+		{0x00B0, 0x00B4, 1},
+		{0x00B6, 0x00BA, 1},
+		{0x00BC, 0x00BF, 1},
+		{0x00C6, 0x00C6, 1},
+		{0x00D0, 0x00D0, 1},
+		{0x00D7, 0x00D8, 1},
+		{0x00DE, 0x00E1, 1},
+		{0x00E6, 0x00E6, 1},
+		{0x00E8, 0x00EA, 1},
+		{0x00EC, 0x00ED, 1},
+		{0x00F0, 0x00F0, 1},
+		{0x00F2, 0x00F3, 1},
+		{0x00F7, 0x00FA, 1},
+		{0x00FC, 0x00FC, 1},
+		{0x00FE, 0x00FE, 1},
+		{0x0101, 0x0101, 1},
+		{0x0111, 0x0111, 1},
+		{0x0113, 0x0113, 1},
+		{0x011B, 0x011B, 1},
+		{0x0126, 0x0127, 1},
+		{0x012B, 0x012B, 1},
+		{0x0131, 0x0133, 1},
+		{0x0138, 0x0138, 1},
+		{0x013F, 0x0142, 1},
+		{0x0144, 0x0144, 1},
+		{0x0148, 0x014B, 1},
+		{0x014D, 0x014D, 1},
+		{0x0152, 0x0153, 1},
+		{0x0166, 0x0167, 1},
+		{0x016B, 0x016B, 1},
+		{0x01CE, 0x01CE, 1},
+		{0x01D0, 0x01D0, 1},
+		{0x01D2, 0x01D2, 1},
+		{0x01D4, 0x01D4, 1},
+		{0x01D6, 0x01D6, 1},
+		{0x01D8, 0x01D8, 1},
+		{0x01DA, 0x01DA, 1},
+		{0x01DC, 0x01DC, 1},
+		{0x0251, 0x0251, 1},
+		{0x0261, 0x0261, 1},
+		{0x02C4, 0x02C4, 1},
+		{0x02C7, 0x02C7, 1},
+		{0x02C9, 0x02CB, 1},
+		{0x02CD, 0x02CD, 1},
+		{0x02D0, 0x02D0, 1},
+		{0x02D8, 0x02DB, 1},
+		{0x02DD, 0x02DD, 1},
+		{0x02DF, 0x02DF, 1},
+		{0x0300, 0x036F, 1},
+		{0x0391, 0x03A1, 1},
+		{0x03A3, 0x03A9, 1},
+		{0x03B1, 0x03C1, 1},
+		{0x03C3, 0x03C9, 1},
+		{0x0401, 0x0401, 1},
+		{0x0410, 0x044F, 1},
+		{0x0451, 0x0451, 1},
+		{0x2010, 0x2010, 1},
+		{0x2013, 0x2016, 1},
+		{0x2018, 0x2019, 1},
+		{0x201C, 0x201D, 1},
+		{0x2020, 0x2022, 1},
+		{0x2024, 0x2027, 1},
+		{0x2030, 0x2030, 1},
+		{0x2032, 0x2033, 1},
+		{0x2035, 0x2035, 1},
+		{0x203B, 0x203B, 1},
+		{0x203E, 0x203E, 1},
+		{0x2074, 0x2074, 1},
+		{0x207F, 0x207F, 1},
+		{0x2081, 0x2084, 1},
+		{0x20AC, 0x20AC, 1},
+		{0x2103, 0x2103, 1},
+		{0x2105, 0x2105, 1},
+		{0x2109, 0x2109, 1},
+		{0x2113, 0x2113, 1},
+		{0x2116, 0x2116, 1},
+		{0x2121, 0x2122, 1},
+		{0x2126, 0x2126, 1},
+		{0x212B, 0x212B, 1},
+		{0x2153, 0x2154, 1},
+		{0x215B, 0x215E, 1},
+		{0x2160, 0x216B, 1},
+		{0x2170, 0x2179, 1},
+		{0x2189, 0x2189, 1},
+		{0x2190, 0x2199, 1},
+		{0x21B8, 0x21B9, 1},
+		{0x21D2, 0x21D2, 1},
+		{0x21D4, 0x21D4, 1},
+		{0x21E7, 0x21E7, 1},
+		{0x2200, 0x2200, 1},
+		{0x2202, 0x2203, 1},
+		{0x2207, 0x2208, 1},
+		{0x220B, 0x220B, 1},
+		{0x220F, 0x220F, 1},
+		{0x2211, 0x2211, 1},
+		{0x2215, 0x2215, 1},
+		{0x221A, 0x221A, 1},
+		{0x221D, 0x2220, 1},
+		{0x2223, 0x2223, 1},
+		{0x2225, 0x2225, 1},
+		{0x2227, 0x222C, 1},
+		{0x222E, 0x222E, 1},
+		{0x2234, 0x2237, 1},
+		{0x223C, 0x223D, 1},
+		{0x2248, 0x2248, 1},
+		{0x224C, 0x224C, 1},
+		{0x2252, 0x2252, 1},
+		{0x2260, 0x2261, 1},
+		{0x2264, 0x2267, 1},
+		{0x226A, 0x226B, 1},
+		{0x226E, 0x226F, 1},
+		{0x2282, 0x2283, 1},
+		{0x2286, 0x2287, 1},
+		{0x2295, 0x2295, 1},
+		{0x2299, 0x2299, 1},
+		{0x22A5, 0x22A5, 1},
+		{0x22BF, 0x22BF, 1},
+		{0x2312, 0x2312, 1},
+		{0x2460, 0x24E9, 1},
+		{0x24EB, 0x254B, 1},
+		{0x2550, 0x2573, 1},
+		{0x2580, 0x258F, 1},
+		{0x2592, 0x2595, 1},
+		{0x25A0, 0x25A1, 1},
+		{0x25A3, 0x25A9, 1},
+		{0x25B2, 0x25B3, 1},
+		{0x25B6, 0x25B7, 1},
+		{0x25BC, 0x25BD, 1},
+		{0x25C0, 0x25C1, 1},
+		{0x25C6, 0x25C8, 1},
+		{0x25CB, 0x25CB, 1},
+		{0x25CE, 0x25D1, 1},
+		{0x25E2, 0x25E5, 1},
+		{0x25EF, 0x25EF, 1},
+		{0x2605, 0x2606, 1},
+		{0x2609, 0x2609, 1},
+		{0x260E, 0x260F, 1},
+		{0x261C, 0x261C, 1},
+		{0x261E, 0x261E, 1},
+		{0x2640, 0x2640, 1},
+		{0x2642, 0x2642, 1},
+		{0x2660, 0x2661, 1},
+		{0x2663, 0x2665, 1},
+		{0x2667, 0x266A, 1},
+		{0x266C, 0x266D, 1},
+		{0x266F, 0x266F, 1},
+		{0x269E, 0x269F, 1},
+		{0x26BF, 0x26BF, 1},
+		{0x26C6, 0x26CD, 1},
+		{0x26CF, 0x26D3, 1},
+		{0x26D5, 0x26E1, 1},
+		{0x26E3, 0x26E3, 1},
+		{0x26E8, 0x26E9, 1},
+		{0x26EB, 0x26F1, 1},
+		{0x26F4, 0x26F4, 1},
+		{0x26F6, 0x26F9, 1},
+		{0x26FB, 0x26FC, 1},
+		{0x26FE, 0x26FF, 1},
+		{0x273D, 0x273D, 1},
+		{0x2776, 0x277F, 1},
+		{0x2B56, 0x2B59, 1},
+		{0x3248, 0x324F, 1},
+		{0xE000, 0xF8FF, 1},
+		{0xFE00, 0xFE0F, 1},
+		{0xFFFD, 0xFFFD, 1},
+	},
+	R32: []unicode.Range32{
+		{0x1F100, 0x1F10A, 1},
+		{0x1F110, 0x1F12D, 1},
+		{0x1F130, 0x1F169, 1},
+		{0x1F170, 0x1F18D, 1},
+		{0x1F18F, 0x1F190, 1},
+		{0x1F19B, 0x1F1AC, 1},
+		{0xE0100, 0xE01EF, 1},
+		{0xF0000, 0xFFFFD, 1},
+		{0x100000, 0x10FFFD, 1},
+	},
+}
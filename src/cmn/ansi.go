@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CSI sequences, e.g. SGR `ESC[0;31m`, OSC sequences terminated by BEL or ST and
+// two-character escape sequences
+var reAnsi = regexp.MustCompile("\x1b\\[[0-?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(?:\x07|\x1b\\\\)|\x1b[@-Z\\\\-_]")
+
+// An escape sequence s starts with, see ansiPrefix
+var reAnsiPrefix = regexp.MustCompile("^(?:" + reAnsi.String() + ")")
+
+// Parameters of SGR sequences, e.g. `1;34`
+var reSgrParams = regexp.MustCompile(`^[0-9;]*$`)
+
+// AnsiReset resets all SGR attributes, e.g. colors
+const AnsiReset = "\x1b[0m"
+
+// HasAnsi reports whether s contains an escape sequence
+func HasAnsi(s string) bool {
+	return strings.IndexByte(s, '\x1b') >= 0
+}
+
+// StripAnsi removes terminal escape sequences, e.g. colors of ANSIBLE_FORCE_COLOR output
+func StripAnsi(s string) string {
+	if !HasAnsi(s) {
+		return s
+	}
+
+	return reAnsi.ReplaceAllString(s, "")
+}
+
+// ansiPrefix returns the escape sequence s starts with or "" if none
+func ansiPrefix(s string) string {
+	if !strings.HasPrefix(s, "\x1b") {
+		return ""
+	}
+
+	return reAnsiPrefix.FindString(s)
+}
+
+// IsSgrParams reports whether s is a list of SGR parameters, e.g. `1;34` for bold blue
+func IsSgrParams(s string) bool {
+	return reSgrParams.MatchString(s)
+}
+
+// Sgr renders s with SGR parameters `params`, e.g. `1;34` for bold blue, and resets
+// attributes after it. Empty `params` or s results in s as is.
+func Sgr(s string, params string) string {
+	if params == "" || s == "" {
+		return s
+	}
+
+	return "\x1b[" + params + "m" + s + AnsiReset
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+)
+
+func TestStripAnsi(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "ok: [web01]", "ok: [web01]"},
+		{"sgr", "\x1b[0;32mok: [web01]\x1b[0m", "ok: [web01]"},
+		{"sgr, no params", "\x1b[mPLAY [Web]", "PLAY [Web]"},
+		{"bold color", "\x1b[1;31mfatal\x1b[0m: [db01]", "fatal: [db01]"},
+		{"erase line", "\x1b[2Kplay #1", "play #1"},
+		{"osc title", "\x1b]0;ansible\x07TASK [Ping]", "TASK [Ping]"},
+		{"osc, st terminated", "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"two-char sequence", "\x1bMabc", "abc"},
+		{"unicode", "\x1b[0;33mПривет 你好\x1b[0m", "Привет 你好"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tst.DiffError(t, tt.want, StripAnsi(tt.value))
+		})
+	}
+
+	t.Run("HasAnsi()", func(t *testing.T) {
+		tst.DiffError(t, []bool{false, true}, []bool{HasAnsi("ok"), HasAnsi("\x1b[0mok")})
+	})
+}
+
+func TestSgr(t *testing.T) {
+	tst.DiffError(t, "\x1b[1;34mplay\x1b[0m", Sgr("play", "1;34"))
+	tst.DiffError(t, "play", Sgr("play", ""))
+	tst.DiffError(t, "", Sgr("", "1;34"))
+}
+
+func TestIsSgrParams(t *testing.T) {
+	tst.DiffError(t, []bool{true, true, true, false, false}, []bool{
+		IsSgrParams(""), IsSgrParams("32"), IsSgrParams("1;38;5;208"), IsSgrParams("red"), IsSgrParams("1m"),
+	})
+}
+
+func TestAnsiWidthAndChop(t *testing.T) {
+	colored := Sgr("Привет", "32") + " " + Sgr("你好", "1")
+
+	t.Run("Widthers ignore escape sequences", func(t *testing.T) {
+		tst.DiffError(t, 9, RunesWidther{}.Width(colored))
+		tst.DiffError(t, 11, MonospaceWidther{}.Width(colored))
+		tst.DiffError(t, "\x1b[32mok\x1b[0m   ", PadRightFunc(Sgr("ok", "32"), ' ', 5, RunesWidther{}.Width))
+	})
+
+	t.Run("Chop keeps escape sequences whole", func(t *testing.T) {
+		tst.DiffError(t, "\x1b[32mПри\x1b[0m", ChopLineFunc(colored, 3, RunesWidther{}.Width))
+		tst.DiffError(t, "\x1b[32mПривет\x1b[0m \x1b[1m你\x1b[0m", ChopLineFunc(colored, 9, MonospaceWidther{}.Width))
+		tst.DiffError(t, "\x1b[32mПри\x1b[0m>", ChopMarkLine(colored, 4, ">"))
+		tst.DiffError(t, colored, ChopMarkLine(colored, 9, ">"))
+	})
+
+	t.Run("Chop drops escape sequences of no visible text", func(t *testing.T) {
+		tst.DiffError(t, "\x1b[32mПривет\x1b[0m \x1b[0m", ChopLineFunc(colored, 8, MonospaceWidther{}.Width))
+		tst.DiffError(t, "ok", ChopLineFunc("ok"+Sgr("!", "32"), 2, RunesWidther{}.Width))
+	})
+}
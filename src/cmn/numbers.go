@@ -4,6 +4,8 @@
 
 package cmn
 
+import "sort"
+
 func Max(x, y int) int {
 	if x > y {
 		return x
@@ -19,3 +21,20 @@ func Min(x, y int) int {
 
 	return y
 }
+
+// Percentile returns the p-th percentile of values by the nearest-rank method, i.e. the
+// smallest value that at least p percent of values don't exceed. Empty values result in 0.
+func Percentile(values []int, p int) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+
+	rank := (p*len(sorted) + 99) / 100
+	rank = Min(Max(rank, 1), len(sorted))
+
+	return sorted[rank-1]
+}
@@ -51,3 +51,26 @@ func TestMin(t *testing.T) {
 		})
 	}
 }
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		values []int
+		p      int
+		want   int
+	}{
+		{nil, 90, 0},
+		{[]int{7}, 90, 7},
+		{[]int{5, 1, 4, 2, 3}, 50, 3},
+		{[]int{5, 1, 4, 2, 3}, 90, 5},
+		{[]int{10, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 90, 1},
+		{[]int{10, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 100, 10},
+		{[]int{5, 1, 4}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := Percentile(tt.values, tt.p)
+			tst.DiffError(t, tt.want, got)
+		})
+	}
+}
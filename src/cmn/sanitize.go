@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Styles of control character rendering, see Sanitize
+const (
+	SanitizeEscape  = "escape"  // Go escapes, e.g. `\x1b`, `\r`
+	SanitizeCaret   = "caret"   // Caret notation, e.g. `^[`, `^M`
+	SanitizePicture = "picture" // Unicode control pictures, e.g. `␛`, `␍`
+)
+
+func sanitizeEscape(r rune) string {
+	switch r {
+	case '\a':
+		return `\a`
+	case '\b':
+		return `\b`
+	case '\f':
+		return `\f`
+	case '\n':
+		return `\n`
+	case '\r':
+		return `\r`
+	case '\v':
+		return `\v`
+	}
+
+	if r < 0x80 {
+		return fmt.Sprintf(`\x%02x`, r)
+	}
+
+	return fmt.Sprintf(`\u%04x`, r)
+}
+
+func sanitizeRune(r rune, style string) string {
+	switch style {
+	case SanitizeCaret:
+		switch {
+		case r == 0x7f:
+			return "^?"
+		case r < 0x20:
+			return "^" + string(r+0x40)
+		case r >= 0x80 && r < 0xa0:
+			return "M-^" + string(r-0x80+0x40)
+		}
+
+	case SanitizePicture:
+		switch {
+		case r == 0x7f:
+			return "␡"
+		case r < 0x20:
+			return string(0x2400 + r)
+		}
+	}
+
+	return sanitizeEscape(r)
+}
+
+// IsSanitized reports whether r is rendered visibly by Sanitize. Tab is left as is: it
+// separates fields of the input.
+func IsSanitized(r rune) bool {
+	return r != '\t' && unicode.IsControl(r)
+}
+
+// Sanitize renders control characters visibly in the given style so they can't move
+// the cursor or otherwise affect the terminal. Unknown style results in SanitizeEscape.
+func Sanitize(s string, style string) string {
+	if strings.IndexFunc(s, IsSanitized) < 0 {
+		return s
+	}
+
+	var b strings.Builder
+
+	for _, r := range s {
+		if IsSanitized(r) {
+			b.WriteString(sanitizeRune(r, style))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// SanitizeKeepColors is Sanitize that keeps SGR sequences, i.e. colors. Other escape
+// sequences are stripped.
+func SanitizeKeepColors(s string, style string) string {
+	if !HasAnsi(s) {
+		return Sanitize(s, style)
+	}
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, loc := range reAnsi.FindAllStringIndex(s, -1) {
+		b.WriteString(Sanitize(s[last:loc[0]], style))
+
+		if seq := s[loc[0]:loc[1]]; strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+			b.WriteString(seq)
+		}
+
+		last = loc[1]
+	}
+
+	b.WriteString(Sanitize(s[last:], style))
+
+	return b.String()
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+)
+
+func TestSanitize(t *testing.T) {
+	value := "a\x1bb\rc\x00d\x7fe\u009bf\tg"
+
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{SanitizeEscape, `a\x1bb\rc\x00d\x7fe\u009bf` + "\tg"},
+		{SanitizeCaret, "a^[b^Mc^@d^?eM-^[f\tg"},
+		{SanitizePicture, "a␛b␍c␀d␡e\\u009bf\tg"},
+		{"unknown", `a\x1bb\rc\x00d\x7fe\u009bf` + "\tg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			tst.DiffError(t, tt.want, Sanitize(value, tt.style))
+		})
+	}
+
+	t.Run("no control characters", func(t *testing.T) {
+		tst.DiffError(t, "Привет 你好", Sanitize("Привет 你好", SanitizeEscape))
+	})
+}
+
+func TestSanitizeKeepColors(t *testing.T) {
+	value := "\x1b[0;31mfatal\x1b[0m: \x1b[2Kboom\a"
+
+	tst.DiffError(t, "\x1b[0;31mfatal\x1b[0m: boom^G", SanitizeKeepColors(value, SanitizeCaret))
+	tst.DiffError(t, "plain^G", SanitizeKeepColors("plain\a", SanitizeCaret))
+}
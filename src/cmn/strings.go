@@ -7,6 +7,7 @@ package cmn
 import (
 	"fmt"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/rivo/uniseg"
@@ -24,8 +25,21 @@ type BoxChars struct {
 	Cross    string
 	Hor      string
 	Ver      string
+	IsOpen   bool // Top and bottom borders aren't drawn, e.g. Markdown tables
 }
 
+// Box-drawing styles, see BoxCharsByStyle
+const (
+	BoxStyleAscii      = "ascii"
+	BoxStyleDos        = "dos"
+	BoxStyleRounded    = "rounded"
+	BoxStyleDouble     = "double"
+	BoxStyleHeavy      = "heavy"
+	BoxStyleDashed     = "dashed"
+	BoxStyleMarkdown   = "markdown"
+	BoxStyleBorderless = "borderless"
+)
+
 func BoxCharsAscii() BoxChars {
 	return BoxChars{
 		CornerTL: "+",
@@ -58,6 +72,152 @@ func BoxCharsDos() BoxChars {
 	}
 }
 
+func BoxCharsRounded() BoxChars {
+	box := BoxCharsDos()
+	box.CornerTL = "╭"
+	box.CornerTR = "╮"
+	box.CornerBL = "╰"
+	box.CornerBR = "╯"
+
+	return box
+}
+
+func BoxCharsDouble() BoxChars {
+	return BoxChars{
+		CornerTL: "╔",
+		CornerTR: "╗",
+		CornerBL: "╚",
+		CornerBR: "╝",
+		Left:     "╠",
+		Right:    "╣",
+		Top:      "╦",
+		Bottom:   "╩",
+		Cross:    "╬",
+		Hor:      "═",
+		Ver:      "║",
+	}
+}
+
+func BoxCharsHeavy() BoxChars {
+	return BoxChars{
+		CornerTL: "┏",
+		CornerTR: "┓",
+		CornerBL: "┗",
+		CornerBR: "┛",
+		Left:     "┣",
+		Right:    "┫",
+		Top:      "┳",
+		Bottom:   "┻",
+		Cross:    "╋",
+		Hor:      "━",
+		Ver:      "┃",
+	}
+}
+
+func BoxCharsDashed() BoxChars {
+	box := BoxCharsDos()
+	box.Hor = "┄"
+	box.Ver = "┆"
+
+	return box
+}
+
+// BoxCharsMarkdown draws tables as Markdown pipe tables: no top and bottom borders, the
+// header separated by dashes
+func BoxCharsMarkdown() BoxChars {
+	return BoxChars{
+		Left:   "|",
+		Right:  "|",
+		Cross:  "|",
+		Hor:    "-",
+		Ver:    "|",
+		IsOpen: true,
+	}
+}
+
+// BoxCharsBorderless draws no borders, cells are separated by whitespace only
+func BoxCharsBorderless() BoxChars {
+	return BoxChars{
+		CornerTL: " ",
+		CornerTR: " ",
+		CornerBL: " ",
+		CornerBR: " ",
+		Left:     " ",
+		Right:    " ",
+		Top:      " ",
+		Bottom:   " ",
+		Cross:    " ",
+		Hor:      " ",
+		Ver:      " ",
+		IsOpen:   true,
+	}
+}
+
+// BoxCharsByStyle returns box-drawing characters of a style, see BoxStyle* constants
+func BoxCharsByStyle(style string) (BoxChars, bool) {
+	switch style {
+	case BoxStyleAscii:
+		return BoxCharsAscii(), true
+	case BoxStyleDos:
+		return BoxCharsDos(), true
+	case BoxStyleRounded:
+		return BoxCharsRounded(), true
+	case BoxStyleDouble:
+		return BoxCharsDouble(), true
+	case BoxStyleHeavy:
+		return BoxCharsHeavy(), true
+	case BoxStyleDashed:
+		return BoxCharsDashed(), true
+	case BoxStyleMarkdown:
+		return BoxCharsMarkdown(), true
+	case BoxStyleBorderless:
+		return BoxCharsBorderless(), true
+	}
+
+	return BoxChars{}, false
+}
+
+// IsBlankBorder reports whether a border line is made of whitespace only, e.g. of
+// BoxCharsBorderless, and so isn't worth drawing
+func IsBlankBorder(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// BoxCharsFit returns box unless w measures any of its characters wider than a cell, e.g.
+// DOS box-drawing characters when ambiguous-width characters are wide; BoxCharsAscii then.
+// Borders are made of repeated characters, so wide ones can't fill an odd width.
+func BoxCharsFit(box BoxChars, w Widther) BoxChars {
+	if w == nil {
+		return box
+	}
+
+	chars := []string{box.CornerTL, box.CornerTR, box.CornerBL, box.CornerBR, box.Left, box.Right, box.Top, box.Bottom, box.Cross, box.Hor, box.Ver}
+
+	for _, c := range chars {
+		if w.Width(c) > 1 {
+			return BoxCharsAscii()
+		}
+	}
+
+	return box
+}
+
+// Chop marks, see ChopMark
+const (
+	ChopMarkDefault = "▒"
+	ChopMarkAscii   = ">"
+)
+
+// ChopMark returns ChopMarkDefault unless w measures it wider than a cell, e.g. when
+// ambiguous-width characters are wide; ChopMarkAscii then.
+func ChopMark(w Widther) string {
+	if w != nil && w.Width(ChopMarkDefault) > 1 {
+		return ChopMarkAscii
+	}
+
+	return ChopMarkDefault
+}
+
 // ---
 
 type WidthFunc func(string) int
@@ -84,6 +244,32 @@ func WidthMonospace(s string) int {
 	return uniseg.StringWidth(s)
 }
 
+// WidthMonospaceWide returns the monospace width for the given string as WidthMonospace does,
+// except East Asian ambiguous-width characters occupy two cells as in CJK locales.
+func WidthMonospaceWide(s string) int {
+	var (
+		cluster string
+		width   int
+	)
+
+	total := 0
+	state := -1
+
+	for s != "" {
+		cluster, s, width, state = uniseg.FirstGraphemeClusterInString(s, state)
+
+		if width == 1 {
+			if r, _ := utf8.DecodeRuneInString(cluster); unicode.Is(ambiguousWidth, r) {
+				width = 2
+			}
+		}
+
+		total += width
+	}
+
+	return total
+}
+
 // ---
 
 func PadLeftFunc(s string, padWith rune, maxWidth int, fnWidth WidthFunc) string {
@@ -118,12 +304,16 @@ func PadRight(s string, padWith rune, maxRunes int) string {
 
 // ---
 
+// Widthers measure s without escape sequences, see StripAnsi, so colored strings are
+// padded by their visible width
+
 type BytesWidther struct{}
 type RunesWidther struct{}
 type MonospaceWidther struct{}
+type AmbiguousWideWidther struct{}
 
 func (bw BytesWidther) Width(s string) int {
-	return len(s)
+	return len(StripAnsi(s))
 }
 
 func (bw BytesWidther) String() string {
@@ -131,7 +321,7 @@ func (bw BytesWidther) String() string {
 }
 
 func (rw RunesWidther) Width(s string) int {
-	return utf8.RuneCountInString(s)
+	return utf8.RuneCountInString(StripAnsi(s))
 }
 
 func (rw RunesWidther) String() string {
@@ -139,17 +329,37 @@ func (rw RunesWidther) String() string {
 }
 
 func (mw MonospaceWidther) Width(s string) int {
-	return uniseg.StringWidth(s)
+	return uniseg.StringWidth(StripAnsi(s))
 }
 
 func (mw MonospaceWidther) String() string {
 	return "MonospaceWidther{}"
 }
 
+func (aw AmbiguousWideWidther) Width(s string) int {
+	return WidthMonospaceWide(StripAnsi(s))
+}
+
+func (aw AmbiguousWideWidther) String() string {
+	return "AmbiguousWideWidther{}"
+}
+
 // ---
 
 type FnChopMarkLine func(line string, maxWidth int, chopMark string) string
 
+// firstGrapheme returns the first grapheme cluster of s, i.e. a user-perceived character
+func firstGrapheme(s string) string {
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+
+	return cluster
+}
+
+// ChopLineFunc chops line to maxWidth. Line is chopped between grapheme clusters so emoji
+// sequences, flags and combining accents are kept whole; each cluster is measured with fnWidth.
+// Escape sequences, e.g. colors, take no width and are never split; ones with no cluster kept
+// after them are dropped, and a chopped line that has any ends with AnsiReset so colors don't
+// spill over.
 func ChopLineFunc(line string, maxWidth int, fnWidth WidthFunc) string {
 	result := line
 
@@ -157,15 +367,42 @@ func ChopLineFunc(line string, maxWidth int, fnWidth WidthFunc) string {
 		result = ""
 	} else if fnWidth(line) > maxWidth {
 
-		var b strings.Builder
+		var (
+			b       strings.Builder
+			cluster string
+		)
+
 		width := 0
+		state := -1
+		rest := line
+		isAnsi := false
+		pending := "" // Escape sequences written only once a cluster follows them
+
+		for rest != "" {
+			if seq := ansiPrefix(rest); seq != "" {
+				pending += seq
+				rest = rest[len(seq):]
+				state = -1
+				continue
+			}
 
-		for _, r := range line {
-			width += fnWidth(string(r))
+			cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+
+			width += fnWidth(cluster)
 			if width > maxWidth {
 				break
 			}
-			b.WriteRune(r)
+
+			if pending != "" {
+				b.WriteString(pending)
+				pending = ""
+				isAnsi = true
+			}
+			b.WriteString(cluster)
+		}
+
+		if isAnsi {
+			b.WriteString(AnsiReset)
 		}
 
 		result = b.String()
@@ -175,6 +412,8 @@ func ChopLineFunc(line string, maxWidth int, fnWidth WidthFunc) string {
 	return result
 }
 
+// ChopMarkLineFunc chops line to maxWidth, see ChopLineFunc, and appends the first grapheme
+// cluster of chopMark if line was chopped.
 func ChopMarkLineFunc(line string, maxWidth int, chopMark string, fnWidth WidthFunc) string {
 	result := line
 
@@ -183,8 +422,7 @@ func ChopMarkLineFunc(line string, maxWidth int, chopMark string, fnWidth WidthF
 	} else if fnWidth(line) > maxWidth {
 
 		if chopMark != "" {
-			chopRune, _ := utf8.DecodeRuneInString(chopMark)
-			chopMark = string(chopRune)
+			chopMark = firstGrapheme(chopMark)
 			chopMarkWidth := fnWidth(chopMark)
 			if chopMarkWidth > maxWidth {
 				panic(fmt.Errorf("cmn: can't fit `chopMark` with width %d when `maxWidth` is %d", chopMarkWidth, maxWidth))
@@ -199,7 +437,12 @@ func ChopMarkLineFunc(line string, maxWidth int, chopMark string, fnWidth WidthF
 	return result
 }
 
+// ChopMarkLine is ChopMarkLineFunc with width in runes
 func ChopMarkLine(line string, maxWidth int, chopMark string) string {
+	if HasAnsi(line) {
+		return ChopMarkLineFunc(line, maxWidth, chopMark, RunesWidther{}.Width)
+	}
+
 	result := line
 
 	if maxWidth <= 0 {
@@ -207,9 +450,10 @@ func ChopMarkLine(line string, maxWidth int, chopMark string) string {
 	} else if WidthRunes(line) > maxWidth {
 
 		if chopMark != "" {
-			result = fmt.Sprintf("%.*s%.1s", maxWidth-1, line, chopMark)
+			chopMark = firstGrapheme(chopMark)
+			result = ChopLineFunc(line, maxWidth-WidthRunes(chopMark), WidthRunes) + chopMark
 		} else {
-			result = fmt.Sprintf("%.*s", maxWidth, line)
+			result = ChopLineFunc(line, maxWidth, WidthRunes)
 		}
 
 	}
@@ -217,6 +461,100 @@ func ChopMarkLine(line string, maxWidth int, chopMark string) string {
 	return result
 }
 
+// cutWordFunc cuts off the widest head of word at most maxWidth wide, yet at least a grapheme
+// cluster, between grapheme clusters. Escape sequences, e.g. colors, are kept whole: ones of
+// head are repeated at the start of rest so colors carry over, and head ends with AnsiReset.
+func cutWordFunc(word string, maxWidth int, fnWidth WidthFunc) (head string, rest string) {
+	var (
+		b       strings.Builder
+		cluster string
+		tail    string
+	)
+
+	width := 0
+	state := -1
+	rest = word
+	escapes := ""
+	pending := "" // Escape sequences written only once a cluster follows them
+	isCluster := false
+
+	for rest != "" {
+		if seq := ansiPrefix(rest); seq != "" {
+			pending += seq
+			rest = rest[len(seq):]
+			state = -1
+			continue
+		}
+
+		cluster, tail, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+
+		width += fnWidth(cluster)
+		if width > maxWidth && isCluster {
+			break
+		}
+
+		b.WriteString(pending)
+		b.WriteString(cluster)
+		escapes += pending
+		pending = ""
+		rest = tail
+		isCluster = true
+	}
+
+	rest = pending + rest
+	head = b.String()
+
+	if escapes != "" {
+		head += AnsiReset
+		rest = escapes + rest
+	}
+
+	return head, rest
+}
+
+// WrapLineFunc breaks line into lines at most maxWidth wide. Line is broken at spaces; words
+// wider than maxWidth are broken between grapheme clusters, see ChopLineFunc, colors of a
+// broken word carrying over to its next line. Non-positive maxWidth results in line as is.
+func WrapLineFunc(line string, maxWidth int, fnWidth WidthFunc) []string {
+	if maxWidth <= 0 || fnWidth(line) <= maxWidth {
+		return []string{line}
+	}
+
+	lines := make([]string, 0, 2)
+	current := ""
+
+	for _, word := range strings.Fields(line) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+
+		if fnWidth(candidate) <= maxWidth {
+			current = candidate
+			continue
+		}
+
+		if current != "" {
+			lines = append(lines, current)
+		}
+
+		for fnWidth(word) > maxWidth {
+			var head string
+
+			head, word = cutWordFunc(word, maxWidth, fnWidth)
+			lines = append(lines, head)
+		}
+
+		current = word
+	}
+
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
 func ChopMarkLineSelector(w Widther) (fn FnChopMarkLine) {
 
 	switch w.(type) {
@@ -73,6 +73,28 @@ func TestWidthMonospace(t *testing.T) {
 	}
 }
 
+func TestWidthMonospaceWide(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"Hello", 5},
+		{"Привет", 12},
+		{"你好", 4},
+		{"┌─┐", 6},
+		{"▒", 2},
+		{"Cafe\u0301", 4},
+		{"😀", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := WidthMonospaceWide(tt.value)
+			tst.DiffError(t, tt.want, got)
+		})
+	}
+}
+
 func Test_BytesWidther(t *testing.T) {
 	t.Run("Width", func(t *testing.T) {
 		tests := []struct {
@@ -154,6 +176,71 @@ func Test_MonospaceWidther(t *testing.T) {
 	})
 }
 
+func Test_AmbiguousWideWidther(t *testing.T) {
+	t.Run("Width", func(t *testing.T) {
+		tests := []struct {
+			value string
+			want  int
+		}{
+			{"Hello", 5},
+			{"Привет", 12},
+			{"你好", 4},
+		}
+
+		for _, tt := range tests {
+			t.Run("", func(t *testing.T) {
+				got := AmbiguousWideWidther{}.Width(tt.value)
+				tst.DiffError(t, tt.want, got)
+			})
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		want := "AmbiguousWideWidther{}"
+		got := AmbiguousWideWidther{}.String()
+
+		tst.DiffError(t, want, got)
+	})
+}
+
+func TestBoxCharsFit(t *testing.T) {
+	tst.DiffError(t, BoxCharsDos(), BoxCharsFit(BoxCharsDos(), MonospaceWidther{}))
+	tst.DiffError(t, BoxCharsAscii(), BoxCharsFit(BoxCharsDos(), AmbiguousWideWidther{}))
+	tst.DiffError(t, BoxCharsAscii(), BoxCharsFit(BoxCharsAscii(), AmbiguousWideWidther{}))
+}
+
+func TestBoxCharsByStyle(t *testing.T) {
+	styles := []string{
+		BoxStyleAscii, BoxStyleDos, BoxStyleRounded, BoxStyleDouble,
+		BoxStyleHeavy, BoxStyleDashed, BoxStyleMarkdown, BoxStyleBorderless,
+	}
+
+	for _, style := range styles {
+		t.Run(style, func(t *testing.T) {
+			box, ok := BoxCharsByStyle(style)
+
+			tst.DiffError(t, true, ok)
+			tst.DiffError(t, 1, WidthMonospace(box.Hor))
+			tst.DiffError(t, 1, WidthMonospace(box.Ver))
+		})
+	}
+
+	_, ok := BoxCharsByStyle("fancy")
+	tst.DiffError(t, false, ok)
+}
+
+func TestIsBlankBorder(t *testing.T) {
+	tst.DiffError(t, true, IsBlankBorder(""))
+	tst.DiffError(t, true, IsBlankBorder("      "))
+	tst.DiffError(t, false, IsBlankBorder("      |---|"))
+}
+
+func TestChopMark(t *testing.T) {
+	tst.DiffError(t, ChopMarkDefault, ChopMark(RunesWidther{}))
+	tst.DiffError(t, ChopMarkDefault, ChopMark(MonospaceWidther{}))
+	tst.DiffError(t, ChopMarkAscii, ChopMark(AmbiguousWideWidther{}))
+}
+
 func TestPadLeft(t *testing.T) {
 	tests := []struct {
 		value string
@@ -287,6 +374,13 @@ func TestChopLineFunc(t *testing.T) {
 		{"WidthMonospace", "Hello", "Hel", 3, WidthMonospace},
 		{"WidthMonospace", "Привет", "При", 3, WidthMonospace},
 		{"WidthMonospace", "你好", "你", 3, WidthMonospace},
+		{"Graphemes: combining accent", "Cafe\u0301 au lait", "Caf", 4, WidthRunes},
+		{"Graphemes: combining accent", "Cafe\u0301 au lait", "Cafe\u0301", 4, WidthMonospace},
+		{"Graphemes: zwj sequence", "👨‍👩‍👧 family", "", 4, WidthRunes},
+		{"Graphemes: zwj sequence", "👨‍👩‍👧 family", "", 1, WidthMonospace},
+		{"Graphemes: zwj sequence", "👨‍👩‍👧 family", "👨‍👩‍👧", 2, WidthMonospace},
+		{"Graphemes: flags", "🇩🇪🇫🇷", "🇩🇪", 3, WidthMonospace},
+		{"Graphemes: flags", "🇩🇪🇫🇷", "🇩🇪", 3, WidthRunes},
 	}
 
 	for _, tt := range tests {
@@ -341,6 +435,9 @@ func TestChopMarkLineFunc(t *testing.T) {
 		{"WidthMonospace", "Hello", "Hel▒", 4, WidthMonospace},
 		{"WidthMonospace", "Привет", "При▒", 4, WidthMonospace},
 		{"WidthMonospace", "你好", "你好", 4, WidthMonospace},
+		{"Graphemes: combining accent", "Cafe\u0301 au lait", "Cafe\u0301▒", 5, WidthMonospace},
+		{"Graphemes: zwj sequence", "👨‍👩‍👧 family", "👨‍👩‍👧▒", 3, WidthMonospace},
+		{"Graphemes: flags", "🇩🇪🇫🇷 flags", "🇩🇪🇫🇷▒", 5, WidthMonospace},
 	}
 
 	t.Run("panic when maxWidth<chopMarkWidt", func(t *testing.T) {
@@ -388,6 +485,9 @@ func TestChopMarkLine(t *testing.T) {
 		{"WidthRunes", "Hello", "He▒", "▒", 3, WidthRunes},
 		{"WidthRunes", "Привет", "Пр▒", "▒", 3, WidthRunes},
 		{"WidthRunes", "你好", "你好", "▒", 3, WidthRunes},
+		{"Graphemes: combining accent", "Cafe\u0301s", "Caf▒", "▒", 5, WidthRunes},
+		{"Graphemes: flags", "🇩🇪🇫🇷 flags", "🇩🇪▒", "▒", 4, WidthRunes},
+		{"Graphemes: chopMark", "Hello", "Hee\u0301", "e\u0301x", 4, WidthRunes},
 	}
 
 	for _, tt := range tests {
@@ -399,6 +499,36 @@ func TestChopMarkLine(t *testing.T) {
 
 }
 
+func TestWrapLineFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		want     []string
+		maxWidth int
+		fnWidth  WidthFunc
+	}{
+		{"fits", "[apt, facts]", []string{"[apt, facts]"}, 12, WidthRunes},
+		{"maxWidth==0", "[apt, facts]", []string{"[apt, facts]"}, 0, WidthRunes},
+		{"empty", "", []string{""}, 4, WidthRunes},
+		{"words", "TAGS: [apt, facts, vars]", []string{"TAGS: [apt,", "facts, vars]"}, 12, WidthRunes},
+		{"one word per line", "TAGS: [apt, facts, vars]", []string{"TAGS:", "[apt,", "facts,", "vars]"}, 6, WidthRunes},
+		{"long word", "Gather_the_package_facts now", []string{"Gather_the", "_package_f", "acts now"}, 10, WidthRunes},
+		{"monospace", "你好 世界 你好", []string{"你好", "世界", "你好"}, 5, WidthMonospace},
+		{"runes", "你好 世界 你好", []string{"你好 世界", "你好"}, 5, WidthRunes},
+		{"cluster wider than maxWidth", "你好", []string{"你", "好"}, 1, WidthMonospace},
+		{"graphemes", "Cafe\u0301s", []string{"Caf", "e\u0301s"}, 4, WidthRunes},
+		{"colored word", "aaaaaa \x1b[31mbbbbbbbb\x1b[0m", []string{"aaaa", "aa", "\x1b[31mbbbb\x1b[0m", "\x1b[31mbbbb\x1b[0m"}, 4, RunesWidther{}.Width},
+		{"color change within word", "\x1b[31mab\x1b[32mcd\x1b[0m", []string{"\x1b[31mab\x1b[0m", "\x1b[31m\x1b[32mcd\x1b[0m"}, 2, RunesWidther{}.Width},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapLineFunc(tt.value, tt.maxWidth, tt.fnWidth)
+			tst.DiffError(t, tt.want, got)
+		})
+	}
+}
+
 func TestChopMarkLineSelector(t *testing.T) {
 	t.Run("returns `ChopMarkLine` function when Widther is `RunesWidther`", func(t *testing.T) {
 		var fnWant FnChopMarkLine = ChopMarkLine
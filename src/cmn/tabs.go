@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import "strings"
+
+// DefaultTabStop is the tab stop of most terminals
+const DefaultTabStop = 8
+
+// ExpandTabs replaces tabs with spaces up to the next multiple of tabStop as a terminal
+// would, so the result is measured and padded correctly. Columns are counted with fnWidth,
+// escape sequences occupy none. Tab stop less than 1 is treated as 1.
+func ExpandTabs(s string, tabStop int, fnWidth WidthFunc) string {
+	if strings.IndexByte(s, '\t') < 0 {
+		return s
+	}
+
+	if tabStop < 1 {
+		tabStop = 1
+	}
+
+	var b strings.Builder
+
+	col := 0
+
+	for i, part := range strings.Split(s, "\t") {
+		if i > 0 {
+			pad := tabStop - col%tabStop
+
+			b.WriteString(strings.Repeat(" ", pad))
+			col += pad
+		}
+
+		b.WriteString(part)
+		col += fnWidth(StripAnsi(part))
+	}
+
+	return b.String()
+}
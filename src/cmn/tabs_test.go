@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package cmn
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+)
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tabStop int
+		fnWidth WidthFunc
+		want    string
+	}{
+		{"no tabs", "Test TAGS: []", 8, WidthRunes, "Test TAGS: []"},
+		{"leading tab", "\tTest", 8, WidthRunes, "        Test"},
+		{"next stop", "Test\tTAGS: []", 8, WidthRunes, "Test    TAGS: []"},
+		{"at stop", "Test1234\tTAGS", 8, WidthRunes, "Test1234        TAGS"},
+		{"consecutive tabs", "a\t\tb", 4, WidthRunes, "a       b"},
+		{"tab stop 2", "abc\td", 2, WidthRunes, "abc d"},
+		{"tab stop 0", "a\t\tb", 0, WidthRunes, "a  b"},
+		{"runes", "你好\tb", 8, WidthRunes, "你好      b"},
+		{"monospace", "你好\tb", 8, WidthMonospace, "你好    b"},
+		{"escape sequences", "\x1b[0;32mok\x1b[0m\tb", 4, WidthRunes, "\x1b[0;32mok\x1b[0m  b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tst.DiffError(t, tt.want, ExpandTabs(tt.value, tt.tabStop, tt.fnWidth))
+		})
+	}
+}
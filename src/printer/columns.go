@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+// Columns of the task table
+const (
+	ColumnBlock    = "block"
+	ColumnName     = "name"
+	ColumnTags     = "tags"
+	ColumnPlay     = "play"      // Play number
+	ColumnIndex    = "index"     // Index of the task within its play
+	ColumnNumber   = "number"    // Index of the task among all printed tasks
+	ColumnTagCount = "tag-count" // Count of the task tags
+	ColumnLine     = "line"      // Line of the input the task is listed on
+)
+
+// taskRow is a task along with its position, the values of computed columns
+type taskRow struct {
+	task   *processor.Task
+	play   int
+	index  int
+	number int
+}
+
+// taskColumn defines a column of the task table
+type taskColumn struct {
+	header    string
+	isRight   bool
+	isFixed   bool // Never narrower than its longest value, e.g. a number
+	fnValue   func(r *taskRow) string
+	fnWidths  widthsFunc
+	fnIsEmpty func(t *processor.Task) bool // Reports whether the task has no value; nil if it always has
+}
+
+// widthsFunc sizes a column by Stats of the play being printed and of the whole input
+type widthsFunc func(play *processor.Stats, all *processor.Stats) (typical int, longest int)
+
+// playWidths sizes a column by Stats of the play
+func playWidths(fn func(s *processor.Stats) (int, int)) widthsFunc {
+	return func(play *processor.Stats, _ *processor.Stats) (int, int) {
+		return fn(play)
+	}
+}
+
+// numberWidths sizes a number column by the largest value of the play
+func numberWidths(fnMax func(s *processor.Stats) int) widthsFunc {
+	return playWidths(func(s *processor.Stats) (int, int) {
+		width := len(strconv.Itoa(fnMax(s)))
+
+		return width, width
+	})
+}
+
+// allNumberWidths sizes a number column by the largest value of the whole input
+func allNumberWidths(fnMax func(s *processor.Stats) int) widthsFunc {
+	return func(_ *processor.Stats, all *processor.Stats) (int, int) {
+		return numberWidths(fnMax)(all, all)
+	}
+}
+
+var taskColumns = map[string]*taskColumn{
+	ColumnBlock: {
+		header:    "Block",
+		isRight:   true,
+		fnValue:   func(r *taskRow) string { return r.task.Block },
+		fnIsEmpty: func(t *processor.Task) bool { return t.Block == "" },
+		fnWidths:  playWidths(func(s *processor.Stats) (int, int) { return s.TaskBlockLengthP90, s.LongestTaskBlockLength }),
+	},
+	ColumnName: {
+		header:    "Name",
+		fnValue:   func(r *taskRow) string { return r.task.Name },
+		fnIsEmpty: func(t *processor.Task) bool { return t.Name == "" },
+		fnWidths:  playWidths(func(s *processor.Stats) (int, int) { return s.TaskNameLengthP90, s.LongestTaskNameLength }),
+	},
+	ColumnTags: {
+		header:    "Tags",
+		fnValue:   func(r *taskRow) string { return r.task.Tags },
+		fnIsEmpty: func(t *processor.Task) bool { return t.Tags == "" || t.Tags == "[]" },
+		fnWidths:  playWidths(func(s *processor.Stats) (int, int) { return s.TaskTagsLengthP90, s.LongestTaskTagsLength }),
+	},
+	ColumnPlay: {
+		header:   "Play",
+		isRight:  true,
+		isFixed:  true,
+		fnValue:  func(r *taskRow) string { return strconv.Itoa(r.play) },
+		fnWidths: numberWidths(func(s *processor.Stats) int { return s.MaxPlayNumber }),
+	},
+	ColumnIndex: {
+		header:   "#",
+		isRight:  true,
+		isFixed:  true,
+		fnValue:  func(r *taskRow) string { return strconv.Itoa(r.index) },
+		fnWidths: numberWidths(func(s *processor.Stats) int { return s.MaxPlayTasksCount }),
+	},
+	ColumnNumber: {
+		header:   "No",
+		isRight:  true,
+		isFixed:  true,
+		fnValue:  func(r *taskRow) string { return strconv.Itoa(r.number) },
+		fnWidths: allNumberWidths(func(s *processor.Stats) int { return s.TasksCount }), // Task numbers run across plays
+	},
+	ColumnTagCount: {
+		header:   "#Tags",
+		isRight:  true,
+		isFixed:  true,
+		fnValue:  func(r *taskRow) string { return strconv.Itoa(len(r.task.TagList)) },
+		fnWidths: numberWidths(func(s *processor.Stats) int { return s.MaxTaskTagsCount }),
+	},
+	ColumnLine: {
+		header:   "Line",
+		isRight:  true,
+		isFixed:  true,
+		fnValue:  func(r *taskRow) string { return strconv.Itoa(r.task.Line) },
+		fnWidths: numberWidths(func(s *processor.Stats) int { return s.MaxTaskLine }),
+	},
+}
+
+func DefaultTaskColumns() []string {
+	return []string{ColumnBlock, ColumnName, ColumnTags}
+}
+
+// ParseTaskColumns parses a comma-separated list of task table columns, e.g.
+// `index,name,tags`. Empty spec results in DefaultTaskColumns.
+func ParseTaskColumns(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return DefaultTaskColumns(), nil
+	}
+
+	items := strings.Split(spec, ",")
+	columns := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+
+	for _, item := range items {
+		name := strings.ToLower(strings.TrimSpace(item))
+
+		if _, ok := taskColumns[name]; !ok {
+			return nil, fmt.Errorf("printer.ParseTaskColumns: unknown column %q", item)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("printer.ParseTaskColumns: duplicate column %q", item)
+		}
+
+		seen[name] = true
+		columns = append(columns, name)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+)
+
+func TestParseTaskColumns(t *testing.T) {
+	t.Run("Parses specs", func(t *testing.T) {
+		tests := []struct {
+			spec string
+			want []string
+		}{
+			{"", DefaultTaskColumns()},
+			{"tags,name", []string{ColumnTags, ColumnName}},
+			{"Index, name ,line,tag-count", []string{ColumnIndex, ColumnName, ColumnLine, ColumnTagCount}},
+			{"number,play,block", []string{ColumnNumber, ColumnPlay, ColumnBlock}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.spec, func(t *testing.T) {
+				got, err := ParseTaskColumns(tt.spec)
+
+				tst.DiffError(t, nil, err)
+				tst.DiffError(t, tt.want, got)
+			})
+		}
+	})
+
+	t.Run("Fails on invalid specs", func(t *testing.T) {
+		tests := []struct {
+			spec string
+			want string
+		}{
+			{"name,host", `printer.ParseTaskColumns: unknown column "host"`},
+			{"name,,tags", `printer.ParseTaskColumns: unknown column ""`},
+			{"name,tags,Name", `printer.ParseTaskColumns: duplicate column "Name"`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.spec, func(t *testing.T) {
+				_, err := ParseTaskColumns(tt.spec)
+
+				tst.DiffError(t, tt.want, err.Error())
+			})
+		}
+	})
+}
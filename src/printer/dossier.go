@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+// DossierPrinter renders every play as a self-contained "dossier": a boxed header,
+// play tags, host list, task table and a summary of task tags. It's meant for output of
+// `ansible-playbook --list-hosts --list-tags --list-tasks` but copes with any subset.
+// Plays of run output get a boxed header followed by a result table per task, the play
+// recap closes the dossier.
+type DossierPrinter struct {
+	table *TablePrinter
+}
+
+type tagCount struct {
+	tag   string
+	count int
+}
+
+func NewDossierPrinter() *DossierPrinter {
+	return &DossierPrinter{
+		table: NewTablePrinter(),
+	}
+}
+
+func (dp *DossierPrinter) SetWidther(value cmn.Widther) *DossierPrinter {
+	dp.table.SetWidther(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetMaxLineWidth(value int) *DossierPrinter {
+	dp.table.SetMaxLineWidth(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetIsWrapCells(value bool) *DossierPrinter {
+	dp.table.SetIsWrapCells(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetColumnFit(value map[string]TableColumn) *DossierPrinter {
+	dp.table.SetColumnFit(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetTaskColumns(value []string) *DossierPrinter {
+	dp.table.SetTaskColumns(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetIsKeepEmptyColumns(value bool) *DossierPrinter {
+	dp.table.SetIsKeepEmptyColumns(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetIsPerPlayWidths(value bool) *DossierPrinter {
+	dp.table.SetIsPerPlayWidths(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetTheme(value Theme) *DossierPrinter {
+	dp.table.SetTheme(value)
+
+	return dp
+}
+
+func (dp *DossierPrinter) SetBoxChars(value cmn.BoxChars) *DossierPrinter {
+	dp.table.SetBoxChars(value)
+
+	return dp
+}
+
+// countTaskTags counts tasks per tag. Tags are ordered as in `--list-tags` output if
+// present, otherwise in the order of appearance. Tags known only from `--list-tags`
+// output have zero count.
+func countTaskTags(play *processor.Play) []tagCount {
+	var (
+		order  []string
+		counts = make(map[string]int)
+	)
+
+	if play.TaskTags != nil {
+		order = append(order, play.TaskTags.TagList...)
+
+		for _, tag := range play.TaskTags.TagList {
+			counts[tag] = 0
+		}
+	}
+
+	if play.Tasks != nil {
+		for _, task := range play.Tasks.Tasks {
+			for _, tag := range task.TagList {
+				if _, ok := counts[tag]; !ok {
+					order = append(order, tag)
+				}
+
+				counts[tag]++
+			}
+		}
+	}
+
+	result := make([]tagCount, 0, len(order))
+
+	for _, tag := range order {
+		result = append(result, tagCount{tag, counts[tag]})
+	}
+
+	return result
+}
+
+func (dp *DossierPrinter) printHeader(output io.Writer, name string) {
+	tp := dp.table
+	width := tp.widther.Width(name)
+
+	// A box of no top and bottom borders would make a one-row table of the header
+	if tp.isSeparateTables {
+		tp.printLine(output, tp.padPlay+cmn.Sgr(name, tp.theme.Play))
+		return
+	}
+
+	width = cmn.Max(1, cmn.Min(width, tp.maxCellWidth(tp.indentPlay)))
+	borderTop, _, borderBottom := tp.makeBorders(tp.padPlay, []int{width})
+
+	tp.printBorder(output, borderTop, true)
+	tp.printCells(output, tp.padPlay, []string{name}, []int{width}, nil, []string{tp.theme.Play})
+	tp.printBorder(output, borderBottom, true)
+}
+
+func (dp *DossierPrinter) printHosts(output io.Writer, h *processor.Hosts) {
+	tp := dp.table
+	padSection := strings.Repeat(" ", tp.indentSection)
+
+	line := fmt.Sprintf("%sHosts (%d):", padSection, h.Count)
+	if h.Pattern != "" {
+		line += " " + h.Pattern
+	}
+
+	tp.printLine(output, line)
+
+	for _, line := range layoutGrid(h.Hosts, tp.maxLineWidth-tp.indentTask, defaultGridSeparator, tp.widther.Width) {
+		tp.printLine(output, tp.padTask+line)
+	}
+}
+
+func (dp *DossierPrinter) printTagSummary(output io.Writer, play *processor.Play) {
+	tp := dp.table
+	padSection := strings.Repeat(" ", tp.indentSection)
+	counts := countTaskTags(play)
+
+	tp.printLine(output, fmt.Sprintf("%sTask tags (%d):", padSection, len(counts)))
+
+	items := make([]string, 0, len(counts))
+	for _, tc := range counts {
+		items = append(items, fmt.Sprintf("%s (%d)", tc.tag, tc.count))
+	}
+
+	for _, line := range layoutGrid(items, tp.maxLineWidth-tp.indentTask, defaultGridSeparator, tp.widther.Width) {
+		tp.printLine(output, tp.padTask+line)
+	}
+}
+
+func (dp *DossierPrinter) printPlay(output io.Writer, play *processor.Play, stats *processor.Stats) {
+	tp := dp.table
+	padSection := strings.Repeat(" ", tp.indentSection)
+
+	dp.printHeader(output, play.Name)
+	tp.printLine(output, padSection+"Tags: "+cmn.Sgr(play.Tags, tp.theme.Tags))
+
+	dp.printSections(output, play, stats)
+}
+
+// printSections prints hosts, tasks and the tag summary of a play
+func (dp *DossierPrinter) printSections(output io.Writer, play *processor.Play, stats *processor.Stats) {
+	tp := dp.table
+	padSection := strings.Repeat(" ", tp.indentSection)
+
+	if play.Hosts != nil {
+		dp.printHosts(output, play.Hosts)
+	}
+
+	if play.Tasks != nil {
+		tp.printLine(output, fmt.Sprintf("%sTasks (%d):", padSection, len(play.Tasks.Tasks)))
+
+		if len(play.Tasks.Tasks) > 0 {
+			tp.printTable(output, play.Tasks, stats)
+		}
+	}
+
+	if play.Tasks != nil || play.TaskTags != nil {
+		dp.printTagSummary(output, play)
+	}
+}
+
+// PrintTo prints passthru lines as is, except blank lines and section markers within
+// plays, listed or run ones, which are replaced by the dossier layout. Tables of an open
+// box are laid out as by TablePrinter, with plain play headers.
+func (dp *DossierPrinter) PrintTo(output io.Writer, data *processor.Result) {
+	if dp.table.isOpenLayout() {
+		open := &DossierPrinter{table: dp.table.openLayout()}
+		open.PrintTo(output, data)
+		return
+	}
+
+	isFirstPlay := true
+	isInPlay := false
+	isFirstTask := false
+
+	var current *processor.Play
+
+	fnSeparate := func() {
+		if !isFirstPlay {
+			dp.table.printLine(output, "")
+		}
+
+		isFirstPlay = false
+		isInPlay = true
+		isFirstTask = true
+	}
+	fnStats := statsSelector(data, dp.table.isPerPlayWidths)
+	dp.table.taskNumber = 0
+	dp.table.allStats = data.Stats
+	dp.table.isAfterTable = false
+
+	for _, row := range data.Rows {
+		stats := fnStats(row)
+
+		switch t := row.Data.(type) {
+		case *processor.Play:
+			fnSeparate()
+			dp.printPlay(output, t, stats)
+			current = t
+
+		case *processor.RunPlay:
+			fnSeparate()
+			dp.printHeader(output, t.String())
+			current = nil
+
+		case *processor.RunTask:
+			// Result tables of a play are separated, unlike sections of a listed play
+			if !isFirstTask {
+				dp.table.printLine(output, "")
+			}
+
+			dp.table.printRunTaskTable(output, t)
+			isFirstTask = false
+
+		case *processor.Recap:
+			fnSeparate()
+			dp.table.printRecap(output, t)
+			current = nil
+
+		case processor.Passthru:
+			line := strings.TrimSpace(cmn.StripAnsi(string(t)))
+
+			if isInPlay && (line == "" || line == "tasks:") {
+				continue
+			}
+
+			if cmn.HasAnsi(t.String()) {
+				dp.table.separateLine(output, t.String())
+				fmt.Fprintln(output, t)
+			} else {
+				dp.table.printLine(output, t.String())
+			}
+
+		// Sections are printed as part of their play, ones of no play as they come
+		case *processor.Hosts:
+			if current == nil || current.Hosts != t {
+				dp.printSections(output, &processor.Play{Hosts: t}, stats)
+			}
+
+		case *processor.Tasks:
+			if current == nil || current.Tasks != t {
+				dp.printSections(output, &processor.Play{Tasks: t}, stats)
+			}
+
+		case *processor.TaskTags:
+			if current == nil || current.TaskTags != t {
+				dp.printSections(output, &processor.Play{TaskTags: t}, stats)
+			}
+		}
+
+	}
+}
+
+func (dp *DossierPrinter) Print(data *processor.Result) {
+	dp.PrintTo(os.Stdout, data)
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+func Test_countTaskTags(t *testing.T) {
+	tasks := &processor.Tasks{PlayNumber: 1, Tasks: []*processor.Task{
+		{Name: "Task 1", TagList: []string{"web", "apt"}},
+		{Name: "Task 2", TagList: []string{"web"}},
+	}}
+
+	tests := []struct {
+		name string
+		play *processor.Play
+		want []tagCount
+	}{
+		{
+			name: "no sections",
+			play: &processor.Play{},
+			want: []tagCount{},
+		},
+		{
+			name: "tasks only",
+			play: &processor.Play{Tasks: tasks},
+			want: []tagCount{{"web", 2}, {"apt", 1}},
+		},
+		{
+			name: "task tags order",
+			play: &processor.Play{Tasks: tasks, TaskTags: &processor.TaskTags{TagList: []string{"apt", "never", "web"}}},
+			want: []tagCount{{"apt", 1}, {"never", 0}, {"web", 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := countTaskTags(tt.play)
+
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(tagCount{})); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_DossierPrinterPrintTo(t *testing.T) {
+	var out, want cmn.LineBuilder
+
+	play := &processor.Play{
+		Name:    "play #1 (vps): Test",
+		Tags:    "[web]",
+		TagList: []string{"web"},
+		Hosts:   &processor.Hosts{PlayNumber: 1, Pattern: "['vps']", Count: 2, Hosts: []string{"vps1", "vps2"}},
+		Tasks: &processor.Tasks{PlayNumber: 1, Tasks: []*processor.Task{
+			{Block: "", Name: "Task", Tags: "[apt]", TagList: []string{"apt"}},
+		}},
+	}
+
+	data := &processor.Result{
+		Rows: []*processor.Row{
+			{Indent: 0, Data: processor.Passthru("playbook: playbook.yml")},
+			{Indent: 0, Data: processor.Passthru("")},
+			{Indent: 2, Data: play},
+			{Indent: 4, Data: play.Hosts},
+			{Indent: 0, Data: processor.Passthru("    tasks:")},
+			{Indent: 6, Data: play.Tasks},
+			{Indent: 0, Data: processor.Passthru("")},
+		},
+		Plays: []*processor.Play{play},
+		Stats: &processor.Stats{
+			LongestTaskBlockLength: 0,
+			LongestTaskNameLength:  4,
+			LongestTaskTagsLength:  5,
+		},
+	}
+
+	want.WriteLine("playbook: playbook.yml")
+	want.WriteLine("")
+	want.WriteLine("  +---------------------+")
+	want.WriteLine("  | play #1 (vps): Test |")
+	want.WriteLine("  +---------------------+")
+	want.WriteLine("    Tags: [web]")
+	want.WriteLine("    Hosts (2): ['vps']")
+	want.WriteLine("      vps1  vps2")
+	want.WriteLine("    Tasks (1):")
+	want.WriteLine("      +------+-------+")
+	want.WriteLine("      | Name | Tags  |")
+	want.WriteLine("      +------+-------+")
+	want.WriteLine("      | Task | [apt] |")
+	want.WriteLine("      +------+-------+")
+	want.WriteLine("    Task tags (1):")
+	want.WriteLine("      apt (1)")
+
+	dp := NewDossierPrinter()
+	dp.SetMaxLineWidth(80)
+	dp.PrintTo(&out, data)
+
+	tst.DiffError(t, want.String(), out.String())
+}
+
+func Test_DossierPrinterPrintTo_run(t *testing.T) {
+	var out, want cmn.LineBuilder
+
+	input := "PLAY [Web] *****\n\nTASK [Ping] *****\nok: [web01]\n\nTASK [Install] *****\nchanged: [web01]\n\n" +
+		"PLAY RECAP *****\nweb01 : ok=2 changed=1 unreachable=0 failed=0 skipped=0 rescued=0 ignored=0\n"
+
+	data, err := processor.NewProcessor().SetFormat(processor.FormatRun).Process(bufio.NewScanner(strings.NewReader(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want.WriteLine("  +------------+")
+	want.WriteLine("  | PLAY [Web] |")
+	want.WriteLine("  +------------+")
+	want.WriteLine("    TASK [Ping]")
+	want.WriteLine("      +-------+--------+")
+	want.WriteLine("      | Host  | Status |")
+	want.WriteLine("      +-------+--------+")
+	want.WriteLine("      | web01 | ok     |")
+	want.WriteLine("      +-------+--------+")
+	want.WriteLine("")
+	want.WriteLine("    TASK [Install]")
+	want.WriteLine("      +-------+---------+")
+	want.WriteLine("      | Host  | Status  |")
+	want.WriteLine("      +-------+---------+")
+	want.WriteLine("      | web01 | changed |")
+	want.WriteLine("      +-------+---------+")
+	want.WriteLine("")
+	want.WriteLine("  PLAY RECAP")
+
+	dp := NewDossierPrinter()
+	dp.SetMaxLineWidth(80)
+	dp.PrintTo(&out, data)
+
+	// Recap table is covered by Test_TablePrinter_printRecap
+	got := strings.SplitAfter(out.String(), "  PLAY RECAP\n")[0]
+
+	tst.DiffError(t, want.String(), got)
+}
+
+func Test_DossierPrinterPrintTo_orphanSections(t *testing.T) {
+	var out, want cmn.LineBuilder
+
+	tasks := &processor.Tasks{Tasks: []*processor.Task{{Name: "Task", Tags: "[apt]", TagList: []string{"apt"}}}}
+
+	data := &processor.Result{
+		Rows: []*processor.Row{
+			{Indent: 4, Data: &processor.Hosts{Count: 1, Hosts: []string{"vps1"}}},
+			{Indent: 6, Data: tasks},
+		},
+		Stats: &processor.Stats{LongestTaskNameLength: 4, LongestTaskTagsLength: 5},
+	}
+
+	want.WriteLine("    Hosts (1):")
+	want.WriteLine("      vps1")
+	want.WriteLine("    Tasks (1):")
+	want.WriteLine("      +------+-------+")
+	want.WriteLine("      | Name | Tags  |")
+	want.WriteLine("      +------+-------+")
+	want.WriteLine("      | Task | [apt] |")
+	want.WriteLine("      +------+-------+")
+	want.WriteLine("    Task tags (1):")
+	want.WriteLine("      apt (1)")
+
+	dp := NewDossierPrinter()
+	dp.SetMaxLineWidth(80)
+	dp.PrintTo(&out, data)
+
+	tst.DiffError(t, want.String(), out.String())
+}
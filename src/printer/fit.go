@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+)
+
+// TableColumn configures how a table column is fitted into the line width
+type TableColumn struct {
+	Min    int // Width the column never shrinks below; its header width if less
+	Max    int // Width the column never grows beyond; 0 is unbounded
+	Weight int // Share of the deficit the column gives up; 0 shrinks only once others can't
+}
+
+// DefaultTableColumns gives up Block width first as it repeats across tasks, Name last as
+// it tells tasks apart
+func DefaultTableColumns() map[string]TableColumn {
+	return map[string]TableColumn{
+		ColumnBlock: {Weight: 3},
+		ColumnName:  {Weight: 1},
+		ColumnTags:  {Weight: 2},
+	}
+}
+
+// ParseTableColumns parses comma-separated `column=weight[:min[-max]]` specs, e.g.
+// `block=3,name=2:10,tags=1:8-40`. Columns not mentioned keep their default fitting, columns
+// not in DefaultTableColumns have zero weight by default.
+func ParseTableColumns(spec string) (map[string]TableColumn, error) {
+	columns := DefaultTableColumns()
+
+	if strings.TrimSpace(spec) == "" {
+		return columns, nil
+	}
+
+	fnAtoi := func(s string) (int, error) {
+		if s == "" {
+			return 0, nil
+		}
+
+		value, err := strconv.Atoi(s)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("printer.ParseTableColumns: invalid number %q", s)
+		}
+
+		return value, nil
+	}
+
+	for _, item := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		name = strings.ToLower(name)
+
+		if _, known := taskColumns[name]; !ok || !known {
+			return nil, fmt.Errorf("printer.ParseTableColumns: invalid column spec %q", item)
+		}
+
+		weight, limits, _ := strings.Cut(value, ":")
+		min, max, _ := strings.Cut(limits, "-")
+
+		var (
+			column TableColumn
+			err    error
+		)
+
+		if column.Weight, err = fnAtoi(weight); err != nil {
+			return nil, err
+		}
+
+		if column.Min, err = fnAtoi(min); err != nil {
+			return nil, err
+		}
+
+		if column.Max, err = fnAtoi(max); err != nil {
+			return nil, err
+		}
+
+		if column.Max > 0 && column.Max < column.Min {
+			return nil, fmt.Errorf("printer.ParseTableColumns: max is less than min in %q", item)
+		}
+
+		columns[name] = column
+	}
+
+	return columns, nil
+}
+
+// fitColumn is a column being fitted: its configuration along with its content widths
+type fitColumn struct {
+	TableColumn
+	header  int // Width of the header
+	typical int // Width enough for most of the values, e.g. 90th percentile
+	longest int // Width of the widest value
+}
+
+// shrinkColumns cuts `deficit` off `widths`, not below `floors`, in proportion to the width
+// each column can give up times its weight. Columns of zero weight are cut once weighted
+// ones reach their floors. Returns the deficit left.
+func shrinkColumns(widths, floors, weights []int, deficit int) int {
+	for deficit > 0 {
+		shares := make([]int, len(widths))
+		total := 0
+
+		for _, isZero := range []bool{false, true} {
+			for i := range widths {
+				if widths[i] > floors[i] && (weights[i] == 0) == isZero {
+					shares[i] = cmn.Max(weights[i], 1) * (widths[i] - floors[i])
+					total += shares[i]
+				}
+			}
+
+			if total > 0 {
+				break
+			}
+		}
+
+		if total == 0 {
+			break
+		}
+
+		step := deficit
+
+		for i, share := range shares {
+			if share == 0 {
+				continue
+			}
+
+			cut := (step*share + total - 1) / total
+			cut = cmn.Min(cut, cmn.Min(widths[i]-floors[i], deficit))
+
+			widths[i] -= cut
+			deficit -= cut
+		}
+	}
+
+	return deficit
+}
+
+// fitColumns returns widths of columns that sum up to `available` if possible. Columns
+// start as wide as their longest value within min and max. Then, columns wider than their
+// typical value give up the excess, and if that isn't enough, shrink to their minimums.
+func fitColumns(columns []fitColumn, available int) []int {
+	widths := make([]int, len(columns))
+	typicals := make([]int, len(columns))
+	mins := make([]int, len(columns))
+	weights := make([]int, len(columns))
+	deficit := -available
+
+	for i, c := range columns {
+		mins[i] = cmn.Max(c.Min, c.header)
+		widths[i] = cmn.Max(c.longest, mins[i])
+
+		if c.Max > 0 {
+			widths[i] = cmn.Min(widths[i], cmn.Max(c.Max, mins[i]))
+		}
+
+		typicals[i] = cmn.Min(cmn.Max(c.typical, mins[i]), widths[i])
+		weights[i] = c.Weight
+		deficit += widths[i]
+	}
+
+	if deficit > 0 {
+		deficit = shrinkColumns(widths, typicals, weights, deficit)
+		shrinkColumns(widths, mins, weights, deficit)
+	}
+
+	return widths
+}
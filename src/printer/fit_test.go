@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+)
+
+func TestParseTableColumns(t *testing.T) {
+	t.Run("Parses specs", func(t *testing.T) {
+		tests := []struct {
+			spec string
+			want map[string]TableColumn
+		}{
+			{"", DefaultTableColumns()},
+			{
+				"tags=5",
+				map[string]TableColumn{
+					ColumnBlock: {Weight: 3},
+					ColumnName:  {Weight: 1},
+					ColumnTags:  {Weight: 5},
+				},
+			},
+			{
+				"Block=1:8-20, name=2:10, tags=0:-40",
+				map[string]TableColumn{
+					ColumnBlock: {Min: 8, Max: 20, Weight: 1},
+					ColumnName:  {Min: 10, Weight: 2},
+					ColumnTags:  {Max: 40},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.spec, func(t *testing.T) {
+				got, err := ParseTableColumns(tt.spec)
+
+				tst.DiffError(t, nil, err)
+				tst.DiffError(t, tt.want, got)
+			})
+		}
+	})
+
+	t.Run("Fails on invalid specs", func(t *testing.T) {
+		tests := []struct {
+			spec string
+			want string
+		}{
+			{"block", `printer.ParseTableColumns: invalid column spec "block"`},
+			{"host=1", `printer.ParseTableColumns: invalid column spec "host=1"`},
+			{"name=x", `printer.ParseTableColumns: invalid number "x"`},
+			{"name=1:a-5", `printer.ParseTableColumns: invalid number "a"`},
+			{"tags=1:20-10", `printer.ParseTableColumns: max is less than min in "tags=1:20-10"`},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.spec, func(t *testing.T) {
+				_, err := ParseTableColumns(tt.spec)
+
+				tst.DiffError(t, tt.want, err.Error())
+			})
+		}
+	})
+}
+
+func Test_shrinkColumns(t *testing.T) {
+	tests := []struct {
+		widths  []int
+		floors  []int
+		weights []int
+		deficit int
+		want    []int
+		left    int
+	}{
+		{[]int{10, 10, 10}, []int{0, 0, 0}, []int{1, 1, 1}, 6, []int{8, 8, 8}, 0},
+		{[]int{10, 10, 10}, []int{0, 0, 0}, []int{2, 1, 0}, 6, []int{6, 8, 10}, 0},
+		{[]int{10, 10, 10}, []int{8, 0, 0}, []int{2, 1, 0}, 6, []int{8, 6, 10}, 0},
+		{[]int{10, 10, 10}, []int{8, 8, 0}, []int{2, 1, 0}, 6, []int{8, 8, 8}, 0},
+		{[]int{10, 10, 10}, []int{8, 8, 8}, []int{2, 1, 0}, 8, []int{8, 8, 8}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			left := shrinkColumns(tt.widths, tt.floors, tt.weights, tt.deficit)
+
+			tst.DiffError(t, tt.want, tt.widths)
+			tst.DiffError(t, tt.left, left)
+		})
+	}
+}
+
+func Test_fitColumns(t *testing.T) {
+	columns := []fitColumn{
+		{TableColumn{Weight: 1}, 5, 10, 30},
+		{TableColumn{Weight: 1, Min: 8, Max: 20}, 4, 6, 40},
+	}
+
+	tests := []struct {
+		available int
+		want      []int
+	}{
+		{100, []int{30, 20}},
+		{40, []int{23, 17}},
+		{24, []int{13, 11}},
+		{18, []int{10, 8}},
+		{16, []int{8, 8}},
+		{10, []int{5, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			tst.DiffError(t, tt.want, fitColumns(columns, tt.available))
+		})
+	}
+}
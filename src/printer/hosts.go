@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+const (
+	defaultIndentSection = 4
+	defaultGridSeparator = "  "
+)
+
+// layoutGrid arranges items into columns, top to bottom then left to right, like `ls` does.
+// Every line fits into `maxWidth` unless a single item is wider. Non-positive `maxWidth`
+// results in a single column.
+func layoutGrid(items []string, maxWidth int, separator string, fnWidth cmn.WidthFunc) []string {
+	count := len(items)
+	if count == 0 {
+		return nil
+	}
+
+	cellWidth := 0
+	for _, item := range items {
+		cellWidth = cmn.Max(cellWidth, fnWidth(item))
+	}
+
+	sepWidth := fnWidth(separator)
+	cols := 1
+
+	if maxWidth > 0 {
+		cols = cmn.Max(1, (maxWidth+sepWidth)/(cellWidth+sepWidth))
+	}
+
+	rows := (count + cols - 1) / cols
+	cols = (count + rows - 1) / rows
+
+	lines := make([]string, 0, rows)
+
+	for r := 0; r < rows; r++ {
+		var b strings.Builder
+
+		for c := 0; c < cols; c++ {
+			idx := c*rows + r
+			if idx >= count {
+				break
+			}
+
+			if c > 0 {
+				b.WriteString(separator)
+			}
+
+			if c == cols-1 || idx+rows >= count {
+				b.WriteString(items[idx])
+			} else {
+				b.WriteString(cmn.PadRightFunc(items[idx], ' ', cellWidth, fnWidth))
+			}
+		}
+
+		lines = append(lines, b.String())
+	}
+
+	return lines
+}
+
+func hostsPatternLine(h *processor.Hosts) string {
+	return "pattern: " + h.Pattern
+}
+
+func hostsCountLine(h *processor.Hosts) string {
+	return fmt.Sprintf("hosts (%d):", h.Count)
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+func Test_layoutGrid(t *testing.T) {
+	items := []string{"a1", "b22", "c333", "d4", "e5"}
+
+	tests := []struct {
+		name     string
+		items    []string
+		maxWidth int
+		want     []string
+	}{
+		{"no items", nil, 80, nil},
+		{"maxWidth is 0", items, 0, []string{"a1", "b22", "c333", "d4", "e5"}},
+		{"single column", items, 9, []string{"a1", "b22", "c333", "d4", "e5"}},
+		{"two columns", items, 10, []string{"a1    d4", "b22   e5", "c333"}},
+		{"three columns", items, 16, []string{"a1    c333  e5", "b22   d4"}},
+		{"all in one line", items, 80, []string{"a1    b22   c333  d4    e5"}},
+		{"item wider than maxWidth", []string{"abcdef"}, 3, []string{"abcdef"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := layoutGrid(tt.items, tt.maxWidth, defaultGridSeparator, cmn.WidthRunes)
+
+			tst.DiffError(t, tt.want, got)
+		})
+	}
+}
+
+func Test_TablePrinter_printHostsTable(t *testing.T) {
+	tests := []struct {
+		name         string
+		hosts        *processor.Hosts
+		maxLineWidth int
+		want         []string
+	}{
+		{
+			name:         "no hosts",
+			hosts:        &processor.Hosts{PlayNumber: 1, Pattern: "['none']"},
+			maxLineWidth: 80,
+			want: []string{
+				"    pattern: ['none']",
+				"      +-----------+",
+				"      | Hosts (0) |",
+				"      +-----------+",
+			},
+		},
+		{
+			name:         "hosts",
+			hosts:        &processor.Hosts{PlayNumber: 1, Count: 5, Hosts: []string{"a1", "b22", "c333", "d4", "e5"}},
+			maxLineWidth: 26,
+			want: []string{
+				"      +----------------+",
+				"      | Hosts (5)      |",
+				"      +----------------+",
+				"      | a1    c333  e5 |",
+				"      | b22   d4       |",
+				"      +----------------+",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, want cmn.LineBuilder
+
+			for _, line := range tt.want {
+				want.WriteLine(line)
+			}
+
+			tp := NewTablePrinter()
+			tp.SetMaxLineWidth(tt.maxLineWidth)
+			tp.printHostsTable(&out, tt.hosts)
+
+			tst.DiffError(t, want.String(), out.String())
+		})
+	}
+}
@@ -0,0 +1,391 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+const (
+	defaultJsonIndent = "  "
+
+	// JsonVersion is the version of the document produced by JsonPrinter.
+	// It's bumped on every backward incompatible change of the document layout.
+	JsonVersion = 1
+)
+
+// JsonPrinter serializes processor.Result as a JSON document:
+//
+//	{
+//	  "version": 1,
+//	  "plays": [
+//	    {
+//	      "number": 1,
+//	      "name": "play #1 (vps): Test",
+//	      "host_pattern": "vps",
+//	      "title": "Test",
+//	      "tags": "[]",
+//	      "tag_list": [],
+//	      "hosts": { "pattern": "['vps']", "count": 1, "hosts": ["vps1"] },
+//	      "tasks": [
+//	        { "block": "Block", "name": "Name", "tags": "[Tag1, Tag2]", "tag_list": ["Tag1", "Tag2"], "line": 5 }
+//	      ],
+//	      "task_tags": "[Tag1, Tag2]",
+//	      "task_tag_list": ["Tag1", "Tag2"]
+//	    }
+//	  ],
+//	  "run_plays": [
+//	    {
+//	      "number": 1,
+//	      "name": "Test",
+//	      "tasks": [
+//	        {
+//	          "kind": "TASK", "block": "Block", "name": "Name",
+//	          "results": [ { "host": "vps1", "status": "changed", "item": "", "message": "" } ]
+//	        }
+//	      ]
+//	    }
+//	  ],
+//	  "recap": [
+//	    { "host": "vps1", "ok": 2, "changed": 1, "unreachable": 0, "failed": 0, "skipped": 0, "rescued": 0, "ignored": 0 }
+//	  ],
+//	  "passthru": [ "playbook: playbooks/vsp/playbook_vps.yml", "" ],
+//	  "stats": { "longest_play_description": "play #1 (vps): Test", ... }
+//	}
+//
+// Plays are listed in the order of appearance. "number", "host_pattern" and "title" are
+// parsed from the play header "name". Lines that aren't part of a play or
+// task are collected in "passthru". "tags" holds raw tags as printed by ansible,
+// "tag_list" holds parsed ones. "hosts" comes from `--list-hosts` output and is null
+// otherwise. "task_tags" comes from `--list-tags` output and is empty otherwise. "line" is
+// the line of the input a task is listed on.
+// "run_plays" and "recap" come from playbook run output, see processor.FormatRun and
+// processor.FormatJson: a task lists a result per host and loop item, "kind" is "TASK" or
+// "RUNNING HANDLER" and "status" is a host status as printed by ansible, e.g. "ok".
+// Arrays are never null.
+type JsonPrinter struct {
+	indent string
+	err    error
+}
+
+type jsonTask struct {
+	Block   string   `json:"block"`
+	Name    string   `json:"name"`
+	Tags    string   `json:"tags"`
+	TagList []string `json:"tag_list"`
+	Line    int      `json:"line"`
+}
+
+type jsonHosts struct {
+	Pattern string   `json:"pattern"`
+	Count   int      `json:"count"`
+	Hosts   []string `json:"hosts"`
+}
+
+type jsonPlay struct {
+	Number      int         `json:"number"`
+	Name        string      `json:"name"`
+	HostPattern string      `json:"host_pattern"`
+	Title       string      `json:"title"`
+	Tags        string      `json:"tags"`
+	TagList     []string    `json:"tag_list"`
+	Hosts       *jsonHosts  `json:"hosts"`
+	Tasks       []*jsonTask `json:"tasks"`
+	TaskTags    string      `json:"task_tags"`
+	TaskTagList []string    `json:"task_tag_list"`
+}
+
+type jsonHostResult struct {
+	Host    string `json:"host"`
+	Status  string `json:"status"`
+	Item    string `json:"item"`
+	Message string `json:"message"`
+}
+
+type jsonRunTask struct {
+	Kind    string            `json:"kind"`
+	Block   string            `json:"block"`
+	Name    string            `json:"name"`
+	Results []*jsonHostResult `json:"results"`
+}
+
+type jsonRunPlay struct {
+	Number int            `json:"number"`
+	Name   string         `json:"name"`
+	Tasks  []*jsonRunTask `json:"tasks"`
+}
+
+type jsonRecapHost struct {
+	Host        string `json:"host"`
+	Ok          int    `json:"ok"`
+	Changed     int    `json:"changed"`
+	Unreachable int    `json:"unreachable"`
+	Failed      int    `json:"failed"`
+	Skipped     int    `json:"skipped"`
+	Rescued     int    `json:"rescued"`
+	Ignored     int    `json:"ignored"`
+}
+
+type jsonStats struct {
+	InputFormat                  string `json:"input_format"`
+	LongestPlayDescription       string `json:"longest_play_description"`
+	LongestPlayDescriptionLength int    `json:"longest_play_description_length"`
+	LongestPlayTags              string `json:"longest_play_tags"`
+	LongestPlayTagsLength        int    `json:"longest_play_tags_length"`
+	LongestPlayHostPattern       string `json:"longest_play_host_pattern"`
+	LongestPlayHostPatternLength int    `json:"longest_play_host_pattern_length"`
+	LongestPlayTitle             string `json:"longest_play_title"`
+	LongestPlayTitleLength       int    `json:"longest_play_title_length"`
+	MaxPlayNumber                int    `json:"max_play_number"`
+	LongestTaskBlock             string `json:"longest_task_block"`
+	LongestTaskBlockLength       int    `json:"longest_task_block_length"`
+	LongestTaskName              string `json:"longest_task_name"`
+	LongestTaskNameLength        int    `json:"longest_task_name_length"`
+	LongestTaskDescription       string `json:"longest_task_description"`
+	LongestTaskDescriptionLength int    `json:"longest_task_description_length"`
+	LongestTaskTags              string `json:"longest_task_tags"`
+	LongestTaskTagsLength        int    `json:"longest_task_tags_length"`
+	TaskBlockLengthP90           int    `json:"task_block_length_p90"`
+	TaskNameLengthP90            int    `json:"task_name_length_p90"`
+	TaskTagsLengthP90            int    `json:"task_tags_length_p90"`
+	TasksCount                   int    `json:"tasks_count"`
+	MaxPlayTasksCount            int    `json:"max_play_tasks_count"`
+	MaxTaskTagsCount             int    `json:"max_task_tags_count"`
+	MaxTaskLine                  int    `json:"max_task_line"`
+	LongestTag                   string `json:"longest_tag"`
+	LongestTagLength             int    `json:"longest_tag_length"`
+	LongestHost                  string `json:"longest_host"`
+	LongestHostLength            int    `json:"longest_host_length"`
+	HostsCount                   int    `json:"hosts_count"`
+}
+
+type jsonDocument struct {
+	Version  int              `json:"version"`
+	Plays    []*jsonPlay      `json:"plays"`
+	RunPlays []*jsonRunPlay   `json:"run_plays"`
+	Recap    []*jsonRecapHost `json:"recap"`
+	Passthru []string         `json:"passthru"`
+	Stats    *jsonStats       `json:"stats"`
+}
+
+func NewJsonPrinter() *JsonPrinter {
+	return &JsonPrinter{
+		indent: defaultJsonIndent,
+	}
+}
+
+// SetIndent sets the indentation of nested elements. Empty value results in a compact document.
+func (jp *JsonPrinter) SetIndent(value string) *JsonPrinter {
+	jp.indent = value
+
+	return jp
+}
+
+func newJsonStats(s *processor.Stats) *jsonStats {
+	if s == nil {
+		return &jsonStats{}
+	}
+
+	return &jsonStats{
+		InputFormat:                  s.InputFormat,
+		LongestPlayDescription:       s.LongestPlayDescription,
+		LongestPlayDescriptionLength: s.LongestPlayDescriptionLength,
+		LongestPlayTags:              s.LongestPlayTags,
+		LongestPlayTagsLength:        s.LongestPlayTagsLength,
+		LongestPlayHostPattern:       s.LongestPlayHostPattern,
+		LongestPlayHostPatternLength: s.LongestPlayHostPatternLength,
+		LongestPlayTitle:             s.LongestPlayTitle,
+		LongestPlayTitleLength:       s.LongestPlayTitleLength,
+		MaxPlayNumber:                s.MaxPlayNumber,
+		LongestTaskBlock:             s.LongestTaskBlock,
+		LongestTaskBlockLength:       s.LongestTaskBlockLength,
+		LongestTaskName:              s.LongestTaskName,
+		LongestTaskNameLength:        s.LongestTaskNameLength,
+		LongestTaskDescription:       s.LongestTaskDescription,
+		LongestTaskDescriptionLength: s.LongestTaskDescriptionLength,
+		LongestTaskTags:              s.LongestTaskTags,
+		LongestTaskTagsLength:        s.LongestTaskTagsLength,
+		TaskBlockLengthP90:           s.TaskBlockLengthP90,
+		TaskNameLengthP90:            s.TaskNameLengthP90,
+		TaskTagsLengthP90:            s.TaskTagsLengthP90,
+		TasksCount:                   s.TasksCount,
+		MaxPlayTasksCount:            s.MaxPlayTasksCount,
+		MaxTaskTagsCount:             s.MaxTaskTagsCount,
+		MaxTaskLine:                  s.MaxTaskLine,
+		LongestTag:                   s.LongestTag,
+		LongestTagLength:             s.LongestTagLength,
+		LongestHost:                  s.LongestHost,
+		LongestHostLength:            s.LongestHostLength,
+		HostsCount:                   s.HostsCount,
+	}
+}
+
+func newJsonList(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+func newJsonPlay(number int) *jsonPlay {
+	return &jsonPlay{
+		Number:      number,
+		TagList:     []string{},
+		Tasks:       []*jsonTask{},
+		TaskTagList: []string{},
+	}
+}
+
+func newJsonRunTask(t *processor.RunTask) *jsonRunTask {
+	task := &jsonRunTask{
+		Kind:    t.Kind,
+		Block:   t.Block,
+		Name:    t.Name,
+		Results: make([]*jsonHostResult, 0, len(t.Results)),
+	}
+
+	for _, hr := range t.Results {
+		task.Results = append(task.Results, &jsonHostResult{
+			Host:    hr.Host,
+			Status:  hr.Status,
+			Item:    hr.Item,
+			Message: hr.Message,
+		})
+	}
+
+	return task
+}
+
+func (jp *JsonPrinter) makeDocument(data *processor.Result) *jsonDocument {
+	var (
+		play    *jsonPlay
+		runPlay *jsonRunPlay
+	)
+
+	doc := &jsonDocument{
+		Version:  JsonVersion,
+		Plays:    []*jsonPlay{},
+		RunPlays: []*jsonRunPlay{},
+		Recap:    []*jsonRecapHost{},
+		Passthru: []string{},
+		Stats:    newJsonStats(data.Stats),
+	}
+
+	for _, row := range data.Rows {
+
+		switch t := row.Data.(type) {
+		case *processor.Play:
+			number := t.Number
+			if number == 0 {
+				number = len(doc.Plays) + 1
+			}
+
+			play = newJsonPlay(number)
+			play.Name = t.Name
+			play.HostPattern = t.HostPattern
+			play.Title = t.Title
+			play.Tags = t.Tags
+			play.TagList = newJsonList(t.TagList)
+			doc.Plays = append(doc.Plays, play)
+
+		case *processor.Tasks:
+			if play == nil || play.Number != t.PlayNumber {
+				play = newJsonPlay(t.PlayNumber)
+				doc.Plays = append(doc.Plays, play)
+			}
+
+			for _, task := range t.Tasks {
+				play.Tasks = append(play.Tasks, &jsonTask{
+					Block:   task.Block,
+					Name:    task.Name,
+					Tags:    task.Tags,
+					TagList: newJsonList(task.TagList),
+					Line:    task.Line,
+				})
+			}
+
+		case *processor.Hosts:
+			if play == nil || play.Number != t.PlayNumber {
+				play = newJsonPlay(t.PlayNumber)
+				doc.Plays = append(doc.Plays, play)
+			}
+
+			play.Hosts = &jsonHosts{
+				Pattern: t.Pattern,
+				Count:   t.Count,
+				Hosts:   newJsonList(t.Hosts),
+			}
+
+		case *processor.TaskTags:
+			if play == nil || play.Number != t.PlayNumber {
+				play = newJsonPlay(t.PlayNumber)
+				doc.Plays = append(doc.Plays, play)
+			}
+
+			play.TaskTags = t.Tags
+			play.TaskTagList = newJsonList(t.TagList)
+
+		case *processor.RunPlay:
+			runPlay = &jsonRunPlay{Number: t.Number, Name: t.Name, Tasks: []*jsonRunTask{}}
+			doc.RunPlays = append(doc.RunPlays, runPlay)
+
+		case *processor.RunTask:
+			if runPlay == nil || runPlay.Number != t.PlayNumber {
+				runPlay = &jsonRunPlay{Number: t.PlayNumber, Tasks: []*jsonRunTask{}}
+				doc.RunPlays = append(doc.RunPlays, runPlay)
+			}
+
+			runPlay.Tasks = append(runPlay.Tasks, newJsonRunTask(t))
+
+		case *processor.Recap:
+			for _, rh := range t.Hosts {
+				doc.Recap = append(doc.Recap, &jsonRecapHost{
+					Host:        rh.Host,
+					Ok:          rh.Ok,
+					Changed:     rh.Changed,
+					Unreachable: rh.Unreachable,
+					Failed:      rh.Failed,
+					Skipped:     rh.Skipped,
+					Rescued:     rh.Rescued,
+					Ignored:     rh.Ignored,
+				})
+			}
+
+		default:
+			doc.Passthru = append(doc.Passthru, t.String())
+		}
+
+	}
+
+	return doc
+}
+
+// PrintTo writes the document at once, see Err for a failure
+func (jp *JsonPrinter) PrintTo(output io.Writer, data *processor.Result) {
+	enc := json.NewEncoder(output)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", jp.indent)
+
+	jp.err = nil
+
+	if err := enc.Encode(jp.makeDocument(data)); err != nil {
+		jp.err = fmt.Errorf("JsonPrinter.PrintTo: %w", err)
+	}
+}
+
+// Err returns the error of the last PrintTo, if any. A failed write leaves the document truncated.
+func (jp *JsonPrinter) Err() error {
+	return jp.err
+}
+
+func (jp *JsonPrinter) Print(data *processor.Result) {
+	jp.PrintTo(os.Stdout, data)
+}
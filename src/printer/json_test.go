@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+func TestNewJsonPrinter(t *testing.T) {
+	wjp := &JsonPrinter{
+		indent: defaultJsonIndent,
+	}
+
+	gjp := NewJsonPrinter()
+
+	want := fmt.Sprintf("%#v", wjp)
+	got := fmt.Sprintf("%#v", gjp)
+
+	tst.DiffError(t, want, got)
+}
+
+func Test_JsonPrinterSetIndent(t *testing.T) {
+	jp := NewJsonPrinter()
+	jp.SetIndent("\t")
+
+	want := "\t"
+	got := jp.indent
+
+	tst.DiffError(t, want, got)
+}
+
+func Test_JsonPrinterPrintTo(t *testing.T) {
+
+	t.Run("empty result", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+
+		r := processor.Result{}
+
+		lb.WriteString(`{"version":1,"plays":[],"run_plays":[],"recap":[],"passthru":[],"stats":{`)
+		lb.WriteString(`"input_format":"",`)
+		lb.WriteString(`"longest_play_description":"","longest_play_description_length":0,`)
+		lb.WriteString(`"longest_play_tags":"","longest_play_tags_length":0,`)
+		lb.WriteString(`"longest_play_host_pattern":"","longest_play_host_pattern_length":0,`)
+		lb.WriteString(`"longest_play_title":"","longest_play_title_length":0,"max_play_number":0,`)
+		lb.WriteString(`"longest_task_block":"","longest_task_block_length":0,`)
+		lb.WriteString(`"longest_task_name":"","longest_task_name_length":0,`)
+		lb.WriteString(`"longest_task_description":"","longest_task_description_length":0,`)
+		lb.WriteString(`"longest_task_tags":"","longest_task_tags_length":0,`)
+		lb.WriteString(`"task_block_length_p90":0,"task_name_length_p90":0,"task_tags_length_p90":0,`)
+		lb.WriteString(`"tasks_count":0,"max_play_tasks_count":0,"max_task_tags_count":0,"max_task_line":0,`)
+		lb.WriteString(`"longest_tag":"","longest_tag_length":0,`)
+		lb.WriteLine(`"longest_host":"","longest_host_length":0,"hosts_count":0}}`)
+
+		jp := NewJsonPrinter()
+		jp.SetIndent("")
+		jp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+
+	t.Run("mixed rows", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+
+		r := processor.Result{
+			Rows: []*processor.Row{
+				{Indent: 0, Data: processor.Passthru("playbook: demo.yml")},
+				{Indent: 2, Data: &processor.Play{Name: "play #1 (demo): Demo <play>", Number: 1, HostPattern: "demo", Title: "Demo <play>", Tags: "[p1]", TagList: []string{"p1"}}},
+				{Indent: 0, Data: processor.Passthru("    tasks:")},
+				{Indent: 6, Data: &processor.Tasks{
+					PlayNumber: 1,
+					Tasks: []*processor.Task{
+						{Block: "Block", Name: "Name", Tags: "[t1, t2]", TagList: []string{"t1", "t2"}, Line: 4},
+						{Block: "", Name: "Task 1.2", Tags: "[]", Line: 5},
+					},
+				}},
+				{Indent: 6, Data: &processor.TaskTags{PlayNumber: 1, Tags: "[t1, t2]", TagList: []string{"t1", "t2"}}},
+				{Indent: 2, Data: &processor.Play{Name: "play #2 (demo): Empty", Number: 2, HostPattern: "demo", Title: "Empty", Tags: "[]"}},
+				{Indent: 4, Data: &processor.Hosts{PlayNumber: 2, Pattern: "['demo']", Count: 1, Hosts: []string{"demo1"}}},
+			},
+			Stats: &processor.Stats{
+				LongestTaskBlock:       "Block",
+				LongestTaskBlockLength: 5,
+			},
+		}
+
+		lb.WriteLine(`{`)
+		lb.WriteLine(`  "version": 1,`)
+		lb.WriteLine(`  "plays": [`)
+		lb.WriteLine(`    {`)
+		lb.WriteLine(`      "number": 1,`)
+		lb.WriteLine(`      "name": "play #1 (demo): Demo <play>",`)
+		lb.WriteLine(`      "host_pattern": "demo",`)
+		lb.WriteLine(`      "title": "Demo <play>",`)
+		lb.WriteLine(`      "tags": "[p1]",`)
+		lb.WriteLine(`      "tag_list": [`)
+		lb.WriteLine(`        "p1"`)
+		lb.WriteLine(`      ],`)
+		lb.WriteLine(`      "hosts": null,`)
+		lb.WriteLine(`      "tasks": [`)
+		lb.WriteLine(`        {`)
+		lb.WriteLine(`          "block": "Block",`)
+		lb.WriteLine(`          "name": "Name",`)
+		lb.WriteLine(`          "tags": "[t1, t2]",`)
+		lb.WriteLine(`          "tag_list": [`)
+		lb.WriteLine(`            "t1",`)
+		lb.WriteLine(`            "t2"`)
+		lb.WriteLine(`          ],`)
+		lb.WriteLine(`          "line": 4`)
+		lb.WriteLine(`        },`)
+		lb.WriteLine(`        {`)
+		lb.WriteLine(`          "block": "",`)
+		lb.WriteLine(`          "name": "Task 1.2",`)
+		lb.WriteLine(`          "tags": "[]",`)
+		lb.WriteLine(`          "tag_list": [],`)
+		lb.WriteLine(`          "line": 5`)
+		lb.WriteLine(`        }`)
+		lb.WriteLine(`      ],`)
+		lb.WriteLine(`      "task_tags": "[t1, t2]",`)
+		lb.WriteLine(`      "task_tag_list": [`)
+		lb.WriteLine(`        "t1",`)
+		lb.WriteLine(`        "t2"`)
+		lb.WriteLine(`      ]`)
+		lb.WriteLine(`    },`)
+		lb.WriteLine(`    {`)
+		lb.WriteLine(`      "number": 2,`)
+		lb.WriteLine(`      "name": "play #2 (demo): Empty",`)
+		lb.WriteLine(`      "host_pattern": "demo",`)
+		lb.WriteLine(`      "title": "Empty",`)
+		lb.WriteLine(`      "tags": "[]",`)
+		lb.WriteLine(`      "tag_list": [],`)
+		lb.WriteLine(`      "hosts": {`)
+		lb.WriteLine(`        "pattern": "['demo']",`)
+		lb.WriteLine(`        "count": 1,`)
+		lb.WriteLine(`        "hosts": [`)
+		lb.WriteLine(`          "demo1"`)
+		lb.WriteLine(`        ]`)
+		lb.WriteLine(`      },`)
+		lb.WriteLine(`      "tasks": [],`)
+		lb.WriteLine(`      "task_tags": "",`)
+		lb.WriteLine(`      "task_tag_list": []`)
+		lb.WriteLine(`    }`)
+		lb.WriteLine(`  ],`)
+		lb.WriteLine(`  "run_plays": [],`)
+		lb.WriteLine(`  "recap": [],`)
+		lb.WriteLine(`  "passthru": [`)
+		lb.WriteLine(`    "playbook: demo.yml",`)
+		lb.WriteLine(`    "    tasks:"`)
+		lb.WriteLine(`  ],`)
+		lb.WriteLine(`  "stats": {`)
+		lb.WriteLine(`    "input_format": "",`)
+		lb.WriteLine(`    "longest_play_description": "",`)
+		lb.WriteLine(`    "longest_play_description_length": 0,`)
+		lb.WriteLine(`    "longest_play_tags": "",`)
+		lb.WriteLine(`    "longest_play_tags_length": 0,`)
+		lb.WriteLine(`    "longest_play_host_pattern": "",`)
+		lb.WriteLine(`    "longest_play_host_pattern_length": 0,`)
+		lb.WriteLine(`    "longest_play_title": "",`)
+		lb.WriteLine(`    "longest_play_title_length": 0,`)
+		lb.WriteLine(`    "max_play_number": 0,`)
+		lb.WriteLine(`    "longest_task_block": "Block",`)
+		lb.WriteLine(`    "longest_task_block_length": 5,`)
+		lb.WriteLine(`    "longest_task_name": "",`)
+		lb.WriteLine(`    "longest_task_name_length": 0,`)
+		lb.WriteLine(`    "longest_task_description": "",`)
+		lb.WriteLine(`    "longest_task_description_length": 0,`)
+		lb.WriteLine(`    "longest_task_tags": "",`)
+		lb.WriteLine(`    "longest_task_tags_length": 0,`)
+		lb.WriteLine(`    "task_block_length_p90": 0,`)
+		lb.WriteLine(`    "task_name_length_p90": 0,`)
+		lb.WriteLine(`    "task_tags_length_p90": 0,`)
+		lb.WriteLine(`    "tasks_count": 0,`)
+		lb.WriteLine(`    "max_play_tasks_count": 0,`)
+		lb.WriteLine(`    "max_task_tags_count": 0,`)
+		lb.WriteLine(`    "max_task_line": 0,`)
+		lb.WriteLine(`    "longest_tag": "",`)
+		lb.WriteLine(`    "longest_tag_length": 0,`)
+		lb.WriteLine(`    "longest_host": "",`)
+		lb.WriteLine(`    "longest_host_length": 0,`)
+		lb.WriteLine(`    "hosts_count": 0`)
+		lb.WriteLine(`  }`)
+		lb.WriteLine(`}`)
+
+		jp := NewJsonPrinter()
+		jp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+}
+
+func Test_JsonPrinter_makeDocument_run(t *testing.T) {
+	r := &processor.Result{
+		Rows: []*processor.Row{
+			{Data: processor.Passthru("")},
+			{Data: &processor.RunPlay{Number: 1, Name: "Web"}},
+			{Data: &processor.RunTask{PlayNumber: 1, Kind: processor.KindTask, Block: "nginx", Name: "Install", Results: []*processor.HostResult{
+				{Host: "web01", Status: processor.StatusChanged},
+				{Host: "web02", Status: processor.StatusFailed, Item: "curl", Message: "boom"},
+			}}},
+			{Data: &processor.Recap{Hosts: []*processor.RecapHost{{Host: "web01", Ok: 1, Changed: 1}}}},
+		},
+	}
+
+	doc := NewJsonPrinter().makeDocument(r)
+
+	wantRunPlays := []*jsonRunPlay{{Number: 1, Name: "Web", Tasks: []*jsonRunTask{{
+		Kind:  processor.KindTask,
+		Block: "nginx",
+		Name:  "Install",
+		Results: []*jsonHostResult{
+			{Host: "web01", Status: processor.StatusChanged},
+			{Host: "web02", Status: processor.StatusFailed, Item: "curl", Message: "boom"},
+		},
+	}}}}
+
+	if diff := cmp.Diff(wantRunPlays, doc.RunPlays); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff([]*jsonRecapHost{{Host: "web01", Ok: 1, Changed: 1}}, doc.Recap); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{""}, doc.Passthru); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func Test_JsonPrinterErr(t *testing.T) {
+	jp := NewJsonPrinter()
+
+	jp.PrintTo(failingWriter{}, &processor.Result{})
+
+	if diff := cmp.Diff("JsonPrinter.PrintTo: broken pipe", fmt.Sprint(jp.Err())); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	var out cmn.LineBuilder
+
+	jp.PrintTo(&out, &processor.Result{})
+
+	if jp.Err() != nil {
+		t.Errorf("unexpected error: %v", jp.Err())
+	}
+}
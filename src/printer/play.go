@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+// playHeaderWidth returns width of the play header aligned by formatPlayHeader
+func playHeaderWidth(s *processor.Stats) int {
+	if s.MaxPlayNumber == 0 {
+		return s.LongestPlayDescriptionLength
+	}
+
+	// "play #" + number + " (" + pattern + "): " + title
+	width := len("play #") + len(strconv.Itoa(s.MaxPlayNumber)) + len(" (") + s.LongestPlayHostPatternLength + len("): ") + s.LongestPlayTitleLength
+
+	return cmn.Max(width, s.LongestPlayDescriptionLength)
+}
+
+// formatPlayHeader renders play number, host pattern and title in aligned columns, e.g.
+//
+//	play  #9 (web): Web servers
+//	play #10 (db):  Databases
+//
+// Title is padded as well if `isPadTitle` is set. Plays with an unrecognized header are
+// rendered as is.
+func formatPlayHeader(pl *processor.Play, s *processor.Stats, isPadTitle bool, fnWidth cmn.WidthFunc) string {
+	if pl.Number == 0 {
+		if isPadTitle {
+			return cmn.PadRightFunc(pl.Name, ' ', playHeaderWidth(s), fnWidth)
+		}
+
+		return pl.Name
+	}
+
+	number := cmn.PadLeft("#"+strconv.Itoa(pl.Number), ' ', len(strconv.Itoa(s.MaxPlayNumber))+1)
+	pattern := cmn.PadRightFunc("("+pl.HostPattern+"):", ' ', s.LongestPlayHostPatternLength+3, fnWidth)
+	title := pl.Title
+
+	if isPadTitle {
+		title = cmn.PadRightFunc(title, ' ', s.LongestPlayTitleLength, fnWidth)
+	}
+
+	return fmt.Sprintf("play %s %s %s", number, pattern, title)
+}
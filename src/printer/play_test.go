@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+func Test_formatPlayHeader(t *testing.T) {
+	stats := &processor.Stats{
+		LongestPlayDescriptionLength: 26,
+		LongestPlayHostPatternLength: 10,
+		LongestPlayTitleLength:       11,
+		MaxPlayNumber:                10,
+	}
+
+	tests := []struct {
+		name       string
+		play       *processor.Play
+		isPadTitle bool
+		want       string
+	}{
+		{
+			name: "aligned",
+			play: &processor.Play{Name: "play #9 (db): Databases", Number: 9, HostPattern: "db", Title: "Databases"},
+			want: "play  #9 (db):         Databases",
+		},
+		{
+			name:       "aligned, padded title",
+			play:       &processor.Play{Name: "play #9 (db): Databases", Number: 9, HostPattern: "db", Title: "Databases"},
+			isPadTitle: true,
+			want:       "play  #9 (db):         Databases  ",
+		},
+		{
+			name: "longest",
+			play: &processor.Play{Name: "play #10 (webservers): Web servers", Number: 10, HostPattern: "webservers", Title: "Web servers"},
+			want: "play #10 (webservers): Web servers",
+		},
+		{
+			name: "unrecognized header",
+			play: &processor.Play{Name: "Unrecognized"},
+			want: "Unrecognized",
+		},
+		{
+			name:       "unrecognized header, padded title",
+			play:       &processor.Play{Name: "Unrecognized"},
+			isPadTitle: true,
+			want:       "Unrecognized                      ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatPlayHeader(tt.play, stats, tt.isPadTitle, cmn.WidthRunes)
+
+			tst.DiffError(t, tt.want, got)
+		})
+	}
+
+	t.Run("playHeaderWidth()", func(t *testing.T) {
+		tst.DiffError(t, 34, playHeaderWidth(stats))
+		tst.DiffError(t, 7, playHeaderWidth(&processor.Stats{LongestPlayDescriptionLength: 7}))
+	})
+}
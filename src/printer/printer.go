@@ -19,6 +19,7 @@ const (
 	defaultBlockSeparator  = ": "
 	defaultIndentPlay      = 2
 	defaultIndentTask      = 6
+	defaultWrapMinWidth    = 20 // Width wrapped names leave to the second column at least
 )
 
 type ColumnPrinter struct {
@@ -26,10 +27,17 @@ type ColumnPrinter struct {
 	columnSeparator string
 	blockSeparator  string
 	indentPlay      int
+	indentSection   int
 	indentTask      int
 	maxLineWidth    int
 	isIndentBlock   bool
 	isChopLines     bool
+	isWrapLines     bool
+	isWrapNames     bool
+	isAlignPlays    bool
+	isKeepIndent    bool
+	isPerPlayWidths bool
+	theme           Theme
 }
 
 func NewColumnPrinter() *ColumnPrinter {
@@ -38,23 +46,122 @@ func NewColumnPrinter() *ColumnPrinter {
 		columnSeparator: defaultColumnSeparator,
 		blockSeparator:  defaultBlockSeparator,
 		indentPlay:      defaultIndentPlay,
+		indentSection:   defaultIndentSection,
 		indentTask:      defaultIndentTask,
 	}
 }
 
-func (cp *ColumnPrinter) calcCol1Width(stats *processor.Stats) int {
+// statsSelector returns a func that, called for every row in order, returns Stats to size
+// the row by: Stats of the whole input or, if `isPerPlay`, Stats of the play the row belongs
+// to. Rows preceding the first play and `PLAY RECAP` are sized by Stats of the whole input.
+func statsSelector(data *processor.Result, isPerPlay bool) func(row *processor.Row) *processor.Stats {
+	current := data.Stats
+
+	if !isPerPlay {
+		return func(row *processor.Row) *processor.Stats {
+			return current
+		}
+	}
+
+	plays := data.PlayStats
+	index := 0
+
+	return func(row *processor.Row) *processor.Stats {
+		switch row.Data.(type) {
+		case *processor.Play, *processor.RunPlay:
+			current = data.Stats
+
+			if index < len(plays) {
+				current = plays[index]
+			}
+
+			index++
+
+		case *processor.Recap:
+			current = data.Stats
+		}
+
+		return current
+	}
+}
+
+// keptIndent returns indent detected in the input or `fallback` if not detected
+func keptIndent(detected int, fallback int) int {
+	if detected < 0 {
+		return fallback
+	}
+
+	return detected
+}
+
+// formPassthru re-indents section level passthru lines, e.g. `tasks:`, to `indentSection`.
+// Sections are always indented, so non-positive `detected.Section` means not detected.
+func formPassthru(row *processor.Row, detected processor.Indents, indentSection int) string {
+	line := row.Data.String()
+
+	if detected.Section <= 0 || row.Indent != detected.Section || row.Indent == indentSection {
+		return line
+	}
+
+	return strings.Repeat(" ", indentSection) + strings.TrimLeft(line, " ")
+}
+
+// calcCol1Width returns width of the first column: play headers sized by `plays`, tasks
+// sized by `tasks`
+func (cp *ColumnPrinter) calcCol1Width(plays *processor.Stats, tasks *processor.Stats) int {
 	var play, task int
 
-	play = cp.indentPlay + stats.LongestPlayDescriptionLength
+	if cp.isAlignPlays {
+		play = cp.indentPlay + playHeaderWidth(plays)
+	} else {
+		play = cp.indentPlay + plays.LongestPlayDescriptionLength
+	}
+
 	if cp.isIndentBlock {
-		task = cp.indentTask + stats.LongestTaskBlockLength + cp.widther.Width(cp.blockSeparator) + stats.LongestTaskNameLength
+		task = cp.indentTask + tasks.LongestTaskBlockLength + cp.widther.Width(cp.blockSeparator) + tasks.LongestTaskNameLength
 	} else {
-		task = cp.indentTask + stats.LongestTaskDescriptionLength
+		task = cp.indentTask + tasks.LongestTaskDescriptionLength
 	}
 
 	return cmn.Max(play, task)
 }
 
+// wrapWidths returns widths of the first and the second column of wrapped lines. The second
+// column is at least `defaultWrapMinWidth` wide, lines overflow `maxLineWidth` otherwise.
+// Zero `col2` means no `maxLineWidth` to wrap to.
+func (cp *ColumnPrinter) wrapWidths(plays *processor.Stats, tasks *processor.Stats) (col1 int, col2 int) {
+	col1 = cp.calcCol1Width(plays, tasks)
+	separator := cp.widther.Width(cp.columnSeparator)
+
+	if cp.maxLineWidth <= 0 {
+		return col1, 0
+	}
+
+	if cp.isWrapNames {
+		col1 = cmn.Min(col1, cmn.Max(defaultWrapMinWidth, cp.maxLineWidth-separator-defaultWrapMinWidth))
+	}
+
+	return col1, cmn.Max(defaultWrapMinWidth, cp.maxLineWidth-col1-separator)
+}
+
+// wrapCell wraps `prefix` followed by `text` to width. Continuation lines are aligned under
+// the start of `text`. Lines of `text` are colored with SGR parameters `style` once wrapped.
+func wrapCell(prefix string, text string, style string, width int, fnWidth cmn.WidthFunc) []string {
+	prefixWidth := fnWidth(prefix)
+	lines := cmn.WrapLineFunc(text, width-prefixWidth, fnWidth)
+	pad := strings.Repeat(" ", prefixWidth)
+
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + cmn.Sgr(lines[i], style)
+		} else {
+			lines[i] = pad + cmn.Sgr(lines[i], style)
+		}
+	}
+
+	return lines
+}
+
 func (cp *ColumnPrinter) SetWidther(value cmn.Widther) *ColumnPrinter {
 	cp.widther = value
 
@@ -73,6 +180,51 @@ func (cp *ColumnPrinter) SetIsChopLines(value bool) *ColumnPrinter {
 	return cp
 }
 
+// SetIsWrapLines makes the printer wrap the second column, e.g. tags, onto continuation
+// lines aligned under the column start instead of overflowing `maxLineWidth`
+func (cp *ColumnPrinter) SetIsWrapLines(value bool) *ColumnPrinter {
+	cp.isWrapLines = value
+
+	return cp
+}
+
+// SetIsWrapNames makes wrapping printer wrap the first column, e.g. task names, too so
+// the second column is at least `defaultWrapMinWidth` wide
+func (cp *ColumnPrinter) SetIsWrapNames(value bool) *ColumnPrinter {
+	cp.isWrapNames = value
+
+	return cp
+}
+
+func (cp *ColumnPrinter) SetIsAlignPlays(value bool) *ColumnPrinter {
+	cp.isAlignPlays = value
+
+	return cp
+}
+
+// SetIsKeepIndent makes the printer reproduce indentation detected in the input
+// instead of normalizing it
+func (cp *ColumnPrinter) SetIsKeepIndent(value bool) *ColumnPrinter {
+	cp.isKeepIndent = value
+
+	return cp
+}
+
+// SetIsPerPlayWidths makes the printer size columns of every play by Stats of the play
+// rather than of the whole input, so a long task name widens its own play only
+func (cp *ColumnPrinter) SetIsPerPlayWidths(value bool) *ColumnPrinter {
+	cp.isPerPlayWidths = value
+
+	return cp
+}
+
+// SetTheme makes the printer color play headers, block and task names and tags
+func (cp *ColumnPrinter) SetTheme(value Theme) *ColumnPrinter {
+	cp.theme = value
+
+	return cp
+}
+
 func (cp *ColumnPrinter) SetMaxLineWidth(value int) *ColumnPrinter {
 	cp.maxLineWidth = value
 
@@ -83,37 +235,108 @@ func (cp *ColumnPrinter) PrintTo(output io.Writer, data *processor.Result) {
 	var (
 		col1, col2  string
 		fnPrintLine func(line string)
-		fnFormCol1  func(task *processor.Task) string
+		fnFormCol1  func(block string, name string) string
 	)
 
+	if cp.isKeepIndent {
+		kept := *cp
+		kept.isKeepIndent = false
+		kept.indentPlay = keptIndent(data.Indents.Play, cp.indentPlay)
+		kept.indentSection = keptIndent(data.Indents.Section, cp.indentSection)
+		kept.indentTask = keptIndent(data.Indents.Item, cp.indentTask)
+
+		kept.PrintTo(output, data)
+		return
+	}
+
 	padPlay := strings.Repeat(" ", cp.indentPlay)
+	padSection := strings.Repeat(" ", cp.indentSection)
 	padTask := strings.Repeat(" ", cp.indentTask)
 
+	var col1Width, col2Width int
+
+	stats := data.Stats
+	fnStats := statsSelector(data, cp.isPerPlayWidths)
+
+	// Aligned play headers are sized by global Stats to stay aligned across plays
+	fnSizeColumns := func() {
+		plays := stats
+		if cp.isAlignPlays {
+			plays = data.Stats
+		}
+
+		if cp.isWrapLines {
+			col1Width, col2Width = cp.wrapWidths(plays, stats)
+		} else {
+			col1Width = cp.calcCol1Width(plays, stats)
+		}
+	}
+
+	fnSizeColumns()
+
 	fnFormLine := func(col1 string, col2 string) string {
-		col1Width := cp.calcCol1Width(data.Stats)
 		col1Padded := cmn.PadRightFunc(col1, ' ', col1Width, cp.widther.Width)
 
 		return fmt.Sprint(col1Padded, cp.columnSeparator, col2)
 	}
 
 	if cp.isIndentBlock {
-		fnFormCol1 = func(t *processor.Task) string {
-			blockPadded := cmn.PadLeftFunc(t.Block, ' ', data.Stats.LongestTaskBlockLength, cp.widther.Width)
+		fnFormCol1 = func(block string, name string) string {
+			blockPadded := cmn.PadLeftFunc(cmn.Sgr(block, cp.theme.Block), ' ', stats.LongestTaskBlockLength, cp.widther.Width)
 
-			return fmt.Sprint(padTask, blockPadded, cp.blockSeparator, t.Name)
+			return fmt.Sprint(padTask, blockPadded, cp.blockSeparator, name)
 		}
 
 	} else {
-		fnFormCol1 = func(t *processor.Task) string {
-			return padTask + t.Description()
+		fnFormCol1 = func(block string, name string) string {
+			if block == "" {
+				return padTask + name
+			}
+
+			return fmt.Sprint(padTask, cmn.Sgr(block, cp.theme.Block), cp.blockSeparator, name)
+		}
+	}
+
+	// Row of two columns, the first one is `prefix` followed by `name`. `name` and `col2` are
+	// colored with SGR parameters `nameStyle` and `col2Style`, once wrapped if wrapping.
+	fnPrintRow := func(prefix string, name string, nameStyle string, col2 string, col2Style string) {
+		fnPrintLine(fnFormLine(prefix+cmn.Sgr(name, nameStyle), cmn.Sgr(col2, col2Style)))
+	}
+
+	if cp.isWrapLines {
+		fnPrintRow = func(prefix string, name string, nameStyle string, col2 string, col2Style string) {
+			lines1 := []string{prefix + cmn.Sgr(name, nameStyle)}
+			if cp.isWrapNames {
+				lines1 = wrapCell(prefix, name, nameStyle, col1Width, cp.widther.Width)
+			}
+
+			lines2 := cmn.WrapLineFunc(col2, col2Width, cp.widther.Width)
+			for i := range lines2 {
+				lines2[i] = cmn.Sgr(lines2[i], col2Style)
+			}
+
+			for i := 0; i < cmn.Max(len(lines1), len(lines2)); i++ {
+				var line1, line2 string
+
+				if i < len(lines1) {
+					line1 = lines1[i]
+				}
+
+				if i < len(lines2) {
+					line2 = lines2[i]
+				}
+
+				fnPrintLine(fnFormLine(line1, line2))
+			}
 		}
 	}
 
 	if cp.isChopLines {
 		fnChopMarkLine := cmn.ChopMarkLineSelector(cp.widther)
+		chopMark := cmn.ChopMark(cp.widther)
 
 		fnPrintLine = func(line string) {
-			fmt.Fprintln(output, fnChopMarkLine(line, cp.maxLineWidth, "▒"))
+			fmt.Fprintln(output, fnChopMarkLine(line, cp.maxLineWidth, chopMark))
 		}
 
 	} else {
@@ -124,23 +347,75 @@ func (cp *ColumnPrinter) PrintTo(output io.Writer, data *processor.Result) {
 
 	for _, row := range data.Rows {
 
+		if rowStats := fnStats(row); rowStats != stats {
+			stats = rowStats
+			fnSizeColumns()
+		}
+
 		switch t := row.Data.(type) {
 		case *processor.Play:
-			col1 = padPlay + t.Name
+			if cp.isAlignPlays {
+				col1 = formatPlayHeader(t, data.Stats, false, cp.widther.Width)
+			} else {
+				col1 = t.Name
+			}
 			col2 = "TAGS: " + t.Tags
-			fnPrintLine(fnFormLine(col1, col2))
+			fnPrintRow(padPlay, col1, cp.theme.Play, col2, cp.theme.Tags)
 
 		case *processor.Tasks:
 			for _, task := range t.Tasks {
-				col1 = fnFormCol1(task)
 				col2 = "TAGS: " + task.Tags
-				fnPrintLine(fnFormLine(col1, col2))
+				fnPrintRow(fnFormCol1(task.Block, ""), task.Name, cp.theme.Name, col2, cp.theme.Tags)
+			}
+
+		case *processor.Hosts:
+			if t.Pattern != "" {
+				fnPrintLine(fnFormLine(padSection+hostsPatternLine(t), ""))
+			}
+
+			fnPrintLine(fnFormLine(padSection+hostsCountLine(t), ""))
+
+			for _, line := range layoutGrid(t.Hosts, cp.maxLineWidth-cp.indentTask, defaultGridSeparator, cp.widther.Width) {
+				fnPrintLine(fnFormLine(padTask+line, ""))
+			}
+
+		case *processor.TaskTags:
+			col2 = t.Tags
+			fnPrintRow(padTask, "TASK TAGS:", "", col2, cp.theme.Tags)
+
+		case *processor.RunPlay:
+			fnPrintLine(fnFormLine(padPlay+cmn.Sgr(t.String(), cp.theme.Play), ""))
+
+		case *processor.RunTask:
+			col2 = runStatusSummary(t)
+			fnPrintRow(fnFormCol1(t.Block, ""), t.Name, cp.theme.Name, col2, "")
+
+			for _, hr := range t.Results {
+				if isRunResultDetailed(hr) {
+					fnPrintRow("", "", "", runResultDetailLine(hr), "")
+				}
+			}
+
+		case *processor.Recap:
+			fnPrintLine(fnFormLine(padPlay+t.String(), ""))
+
+			hostWidth := recapHostWidth(t, cp.widther.Width)
+
+			for i, line := range recapLines(t) {
+				col1 = cmn.PadRightFunc(t.Hosts[i].Host, ' ', hostWidth, cp.widther.Width)
+				fnPrintRow(padTask, col1, "", line, "")
 			}
 
 		default:
-			col1 = t.String()
+			col1 = formPassthru(row, data.Indents, cp.indentSection)
 			col2 = ""
-			fnPrintLine(fnFormLine(col1, col2))
+
+			// Colored passthru is printed as is: escape sequences have no width and can't be chopped
+			if cmn.HasAnsi(col1) {
+				fmt.Fprintln(output, col1)
+			} else {
+				fnPrintLine(fnFormLine(col1, col2))
+			}
 		}
 
 	}
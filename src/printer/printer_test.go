@@ -5,7 +5,9 @@
 package printer
 
 import (
+	"bufio"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/keewek/ansible-pretty-print/src/cmn"
@@ -25,6 +27,7 @@ func TestNewColumnPrinter(t *testing.T) {
 		columnSeparator: defaultColumnSeparator,
 		blockSeparator:  defaultBlockSeparator,
 		indentPlay:      defaultIndentPlay,
+		indentSection:   defaultIndentSection,
 		indentTask:      defaultIndentTask,
 	}
 
@@ -72,6 +75,68 @@ func Test_ColumnPrinterSetIsChopLines(t *testing.T) {
 	tst.DiffError(t, want, got)
 }
 
+func Test_ColumnPrinterSetIsWrapLines(t *testing.T) {
+	isWrapLines := true
+	cp := NewColumnPrinter()
+
+	cp.SetIsWrapLines(isWrapLines)
+
+	want := isWrapLines
+	got := cp.isWrapLines
+
+	tst.DiffError(t, want, got)
+}
+
+func Test_ColumnPrinterSetIsWrapNames(t *testing.T) {
+	isWrapNames := true
+	cp := NewColumnPrinter()
+
+	cp.SetIsWrapNames(isWrapNames)
+
+	want := isWrapNames
+	got := cp.isWrapNames
+
+	tst.DiffError(t, want, got)
+}
+
+func Test_ColumnPrinterSetIsAlignPlays(t *testing.T) {
+	isAlignPlays := true
+	cp := NewColumnPrinter()
+
+	cp.SetIsAlignPlays(isAlignPlays)
+
+	want := isAlignPlays
+	got := cp.isAlignPlays
+
+	tst.DiffError(t, want, got)
+}
+
+func Test_ColumnPrinterSetIsKeepIndent(t *testing.T) {
+	isKeepIndent := true
+	cp := NewColumnPrinter()
+
+	cp.SetIsKeepIndent(isKeepIndent)
+
+	want := isKeepIndent
+	got := cp.isKeepIndent
+
+	tst.DiffError(t, want, got)
+}
+
+func Test_ColumnPrinterSetIsPerPlayWidths(t *testing.T) {
+	cp := NewColumnPrinter()
+	cp.SetIsPerPlayWidths(true)
+
+	tst.DiffError(t, true, cp.isPerPlayWidths)
+}
+
+func Test_ColumnPrinterSetTheme(t *testing.T) {
+	cp := NewColumnPrinter()
+	cp.SetTheme(ThemeColorsDark())
+
+	tst.DiffError(t, ThemeColorsDark(), cp.theme)
+}
+
 func Test_ColumnPrinterSetMaxLineWidth(t *testing.T) {
 	maxLineWidth := 40
 	cp := NewColumnPrinter()
@@ -92,6 +157,7 @@ func Test_ColumnPrinter_calcCol1Witdh(t *testing.T) {
 		indentTask     int
 		blockSeparator string
 		isIndentBlock  bool
+		isAlignPlays   bool
 		stats          processor.Stats
 		want           int
 	}{
@@ -193,6 +259,21 @@ func Test_ColumnPrinter_calcCol1Witdh(t *testing.T) {
 			},
 			want: 83,
 		},
+		{
+			name:           "align_plays",
+			indentPlay:     defaultIndentPlay,
+			indentTask:     defaultIndentTask,
+			blockSeparator: defaultBlockSeparator,
+			isAlignPlays:   true,
+			stats: processor.Stats{
+				LongestPlayDescriptionLength: 30,
+				LongestPlayHostPatternLength: 10,
+				LongestPlayTitleLength:       15,
+				MaxPlayNumber:                12,
+				LongestTaskDescriptionLength: 11,
+			},
+			want: 40,
+		},
 	}
 
 	for _, tt := range tests {
@@ -202,8 +283,33 @@ func Test_ColumnPrinter_calcCol1Witdh(t *testing.T) {
 			cp.indentTask = tt.indentTask
 			cp.blockSeparator = tt.blockSeparator
 			cp.isIndentBlock = tt.isIndentBlock
+			cp.isAlignPlays = tt.isAlignPlays
+
+			got := cp.calcCol1Width(&tt.stats, &tt.stats)
 
-			got := cp.calcCol1Width(&tt.stats)
+			tst.DiffError(t, tt.want, got)
+		})
+	}
+}
+
+func Test_formPassthru(t *testing.T) {
+	detected := processor.Indents{Play: 0, Section: 1, Item: 2}
+
+	tests := []struct {
+		name     string
+		row      *processor.Row
+		detected processor.Indents
+		want     string
+	}{
+		{"section level", &processor.Row{Indent: 1, Data: processor.Passthru(" tasks:")}, detected, "    tasks:"},
+		{"other level", &processor.Row{Indent: 0, Data: processor.Passthru("playbook: demo.yml")}, detected, "playbook: demo.yml"},
+		{"not detected", &processor.Row{Indent: 1, Data: processor.Passthru(" tasks:")}, processor.Indents{Play: -1, Section: -1, Item: -1}, " tasks:"},
+		{"zero value", &processor.Row{Indent: 0, Data: processor.Passthru("Passthru")}, processor.Indents{}, "Passthru"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formPassthru(tt.row, tt.detected, defaultIndentSection)
 
 			tst.DiffError(t, tt.want, got)
 		})
@@ -483,6 +589,84 @@ func Test_ColumnPrinterPrintTo(t *testing.T) {
 		})
 	})
 
+	t.Run("row is processor.Hosts", func(t *testing.T) {
+		r := processor.Result{
+			Rows: []*processor.Row{
+				{Indent: 2, Data: &processor.Play{Name: "play #1 (demo): Demo play", Tags: "[]"}},
+				{Indent: 4, Data: &processor.Hosts{PlayNumber: 1, Pattern: "['demo']", Count: 5, Hosts: []string{"a1", "b22", "c333", "d4", "e5"}}},
+			},
+			Stats: &processor.Stats{
+				LongestPlayDescriptionLength: 25,
+			},
+		}
+
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("  play #1 (demo): Demo play    TAGS: []")
+		lb.WriteLine("    pattern: ['demo']          ")
+		lb.WriteLine("    hosts (5):                 ")
+		lb.WriteLine("      a1    c333  e5           ")
+		lb.WriteLine("      b22   d4                 ")
+
+		cp := NewColumnPrinter()
+		cp.SetMaxLineWidth(22)
+		cp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+
+	t.Run("keep indent", func(t *testing.T) {
+		r := processor.Result{
+			Rows: []*processor.Row{
+				{Indent: 0, Data: &processor.Play{Name: "play #1 (demo): Demo play", Tags: "[]"}},
+				{Indent: 1, Data: &processor.Hosts{PlayNumber: 1, Pattern: "['demo']", Count: 1, Hosts: []string{"a1"}}},
+				{Indent: 2, Data: &processor.Tasks{PlayNumber: 1, Tasks: []*processor.Task{{Name: "Task 1.1", Tags: "[]"}}}},
+			},
+			Stats: &processor.Stats{
+				LongestPlayDescriptionLength: 25,
+				LongestTaskDescriptionLength: 8,
+			},
+			Indents: processor.Indents{Play: 0, Section: 1, Item: 2},
+		}
+
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("play #1 (demo): Demo play    TAGS: []")
+		lb.WriteLine(" pattern: ['demo']           ")
+		lb.WriteLine(" hosts (1):                  ")
+		lb.WriteLine("  a1                         ")
+		lb.WriteLine("  Task 1.1                   TAGS: []")
+
+		cp := NewColumnPrinter()
+		cp.SetMaxLineWidth(80)
+		cp.SetIsKeepIndent(true)
+		cp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+
+	t.Run("row is processor.TaskTags", func(t *testing.T) {
+		r := processor.Result{
+			Rows: []*processor.Row{
+				{Indent: 0, Data: &processor.Play{Name: "play #1 (demo): Demo play", Tags: "[p1, demo]"}},
+				{Indent: 0, Data: &processor.TaskTags{PlayNumber: 1, Tags: "[t1, t2]", TagList: []string{"t1", "t2"}}},
+			},
+			Stats: &processor.Stats{
+				LongestPlayDescriptionLength: 25,
+			},
+		}
+
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("  play #1 (demo): Demo play    TAGS: [p1, demo]")
+		lb.WriteLine("      TASK TAGS:               [t1, t2]")
+
+		cp := NewColumnPrinter()
+		cp.PrintTo(&out, &r)
+
+		want := lb.String()
+		got := out.String()
+
+		tst.DiffError(t, want, got)
+	})
+
 	t.Run("mixed rows", func(t *testing.T) {
 		t.Run("nochop", func(t *testing.T) {
 			r := processor.Result{
@@ -552,3 +736,165 @@ func Test_ColumnPrinterPrintTo(t *testing.T) {
 	})
 
 }
+
+func Test_ColumnPrinterPrintTo_wrap(t *testing.T) {
+	r := processor.Result{
+		Rows: []*processor.Row{
+			{Indent: 6, Data: &processor.Tasks{Tasks: []*processor.Task{
+				{Name: "Gather the package facts", Tags: "[apt, facts, vars, bootstrap, never]"},
+				{Name: "Print", Tags: "[vars]"},
+			}}},
+		},
+		Stats: &processor.Stats{LongestTaskDescriptionLength: 24},
+	}
+
+	t.Run("tags", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("      Gather the package facts    TAGS: [apt, facts, vars,")
+		lb.WriteLine("                                  bootstrap, never]")
+		lb.WriteLine("      Print                       TAGS: [vars]")
+
+		cp := NewColumnPrinter().SetIsWrapLines(true).SetMaxLineWidth(60)
+		cp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+
+	t.Run("names", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("      Gather the        TAGS: [apt, facts,")
+		lb.WriteLine("      package facts     vars, bootstrap,")
+		lb.WriteLine("                        never]")
+		lb.WriteLine("      Print             TAGS: [vars]")
+
+		cp := NewColumnPrinter().SetIsWrapLines(true).SetIsWrapNames(true).SetMaxLineWidth(40)
+		cp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+
+	t.Run("second column is at least defaultWrapMinWidth wide", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("      Gather the package facts    TAGS: [apt, facts,")
+		lb.WriteLine("                                  vars, bootstrap,")
+		lb.WriteLine("                                  never]")
+		lb.WriteLine("      Print                       TAGS: [vars]")
+
+		cp := NewColumnPrinter().SetIsWrapLines(true).SetMaxLineWidth(20)
+		cp.PrintTo(&out, &r)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+}
+
+// processLines parses `lines` of listing output, see processor.ProcessLines
+func processLines(t *testing.T, lines ...string) *processor.Result {
+	var lb cmn.LineBuilder
+
+	for _, line := range lines {
+		lb.WriteLine(line)
+	}
+
+	result, err := processor.ProcessLines(bufio.NewScanner(strings.NewReader(lb.String())), cmn.RunesWidther{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func Test_statsSelector(t *testing.T) {
+	data := processLines(t,
+		"playbook: playbook.yml",
+		"  play #1 (web): Web	TAGS: []",
+		"    tasks:",
+		"      Task 1.1	TAGS: []",
+		"      Task 1.2	TAGS: []",
+		"  play #2 (db): Databases	TAGS: []",
+		"    tasks:",
+		"      A task with a long name	TAGS: []",
+	)
+
+	t.Run("global", func(t *testing.T) {
+		fnStats := statsSelector(data, false)
+
+		for _, row := range data.Rows {
+			if got := fnStats(row); got != data.Stats {
+				t.Errorf("want global Stats for row %v", row.Data)
+			}
+		}
+	})
+
+	t.Run("per play", func(t *testing.T) {
+		fnStats := statsSelector(data, true)
+		want := []int{23, 8, 8, 8, 23, 23, 23}
+		got := make([]int, 0, len(data.Rows))
+
+		for _, row := range data.Rows {
+			got = append(got, fnStats(row).LongestTaskNameLength)
+		}
+
+		tst.DiffError(t, want, got)
+	})
+}
+
+func Test_ColumnPrinterPrintTo_perPlayWidths(t *testing.T) {
+	data := processLines(t,
+		"  play #1 (web): Web	TAGS: []",
+		"    tasks:",
+		"      Task 1.1	TAGS: [web]",
+		"  play #2 (db): Databases	TAGS: []",
+		"    tasks:",
+		"      A task with a long name	TAGS: [db]",
+	)
+
+	t.Run("global", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("  play #1 (web): Web             TAGS: []")
+		lb.WriteLine("    tasks:                       ")
+		lb.WriteLine("      Task 1.1                   TAGS: [web]")
+		lb.WriteLine("  play #2 (db): Databases        TAGS: []")
+		lb.WriteLine("    tasks:                       ")
+		lb.WriteLine("      A task with a long name    TAGS: [db]")
+
+		cp := NewColumnPrinter()
+		cp.PrintTo(&out, data)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+
+	t.Run("per play", func(t *testing.T) {
+		var lb, out cmn.LineBuilder
+		lb.WriteLine("  play #1 (web): Web    TAGS: []")
+		lb.WriteLine("    tasks:              ")
+		lb.WriteLine("      Task 1.1          TAGS: [web]")
+		lb.WriteLine("  play #2 (db): Databases        TAGS: []")
+		lb.WriteLine("    tasks:                       ")
+		lb.WriteLine("      A task with a long name    TAGS: [db]")
+
+		cp := NewColumnPrinter().SetIsPerPlayWidths(true)
+		cp.PrintTo(&out, data)
+
+		tst.DiffError(t, lb.String(), out.String())
+	})
+}
+
+func Test_ColumnPrinterPrintTo_theme(t *testing.T) {
+	data := processLines(t,
+		"  play #1 (web): Web	TAGS: [web]",
+		"    tasks:",
+		"      nginx : Install	TAGS: [pkg]",
+	)
+
+	theme := Theme{Play: "1", Block: "33", Name: "2", Tags: "32", Border: "90"}
+
+	var lb, out cmn.LineBuilder
+	lb.WriteLine("  " + cmn.Sgr("play #1 (web): Web", "1") + "    " + cmn.Sgr("TAGS: [web]", "32"))
+	lb.WriteLine("    tasks:              ")
+	lb.WriteLine("      " + cmn.Sgr("nginx", "33") + ": " + cmn.Sgr("Install", "2") + "    " + cmn.Sgr("TAGS: [pkg]", "32"))
+
+	cp := NewColumnPrinter().SetTheme(theme)
+	cp.PrintTo(&out, data)
+
+	tst.DiffError(t, lb.String(), out.String())
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+const runHandlerMark = "[handler] "
+
+// isRunResultDetailed reports whether the host result carries a message worth showing,
+// e.g. a failure reason
+func isRunResultDetailed(hr *processor.HostResult) bool {
+	switch hr.Status {
+	case processor.StatusFailed, processor.StatusFatal, processor.StatusUnreachable:
+		return hr.Message != ""
+	}
+
+	return false
+}
+
+// runResultHost returns host name along with the loop item, if any, e.g. `web01 (git)`
+func runResultHost(hr *processor.HostResult) string {
+	if hr.Item == "" {
+		return hr.Host
+	}
+
+	return fmt.Sprintf("%s (%s)", hr.Host, hr.Item)
+}
+
+// runStatusSummary groups hosts by status in order of first appearance, e.g.
+//
+//	ok: web01, web02; changed: web03
+func runStatusSummary(rt *processor.RunTask) string {
+	statuses := make([]string, 0, 2)
+	hosts := make(map[string][]string)
+
+	for _, hr := range rt.Results {
+		if _, ok := hosts[hr.Status]; !ok {
+			statuses = append(statuses, hr.Status)
+		}
+
+		hosts[hr.Status] = append(hosts[hr.Status], runResultHost(hr))
+	}
+
+	groups := make([]string, 0, len(statuses))
+
+	for _, status := range statuses {
+		groups = append(groups, status+": "+strings.Join(hosts[status], ", "))
+	}
+
+	summary := strings.Join(groups, "; ")
+
+	if rt.Kind == processor.KindRunningHandler {
+		return runHandlerMark + summary
+	}
+
+	return summary
+}
+
+// runResultDetailLine renders a detailed host result, e.g. `fatal: [web03] {"msg": ...}`
+func runResultDetailLine(hr *processor.HostResult) string {
+	return fmt.Sprintf("%s: [%s] %s", hr.Status, runResultHost(hr), hr.Message)
+}
+
+// recapCounterAbbrs are short names of recap counters for narrow tables
+var recapCounterAbbrs = []string{"ok", "chg", "unr", "fail", "skip", "resc", "ign"}
+
+// recapCounters returns names and values of recap counters in the order ansible prints them
+func recapCounters(rh *processor.RecapHost) ([]string, []int) {
+	names := []string{"ok", "changed", "unreachable", "failed", "skipped", "rescued", "ignored"}
+	values := []int{rh.Ok, rh.Changed, rh.Unreachable, rh.Failed, rh.Skipped, rh.Rescued, rh.Ignored}
+
+	return names, values
+}
+
+// recapLines renders recap counters with values aligned across hosts, e.g.
+//
+//	ok=5  changed=3  unreachable=0 ...
+//	ok=12 changed=0  unreachable=1 ...
+func recapLines(r *processor.Recap) []string {
+	widths := make([]int, 0, 7)
+
+	for _, rh := range r.Hosts {
+		_, values := recapCounters(rh)
+
+		for i, value := range values {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+
+			widths[i] = cmn.Max(widths[i], len(strconv.Itoa(value)))
+		}
+	}
+
+	lines := make([]string, 0, len(r.Hosts))
+
+	for _, rh := range r.Hosts {
+		names, values := recapCounters(rh)
+		cells := make([]string, 0, len(values))
+
+		for i, value := range values {
+			cells = append(cells, cmn.PadRight(names[i]+"="+strconv.Itoa(value), ' ', len(names[i])+1+widths[i]))
+		}
+
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+
+	return lines
+}
+
+// recapHostWidth returns width of the longest recap host name
+func recapHostWidth(r *processor.Recap, fnWidth cmn.WidthFunc) int {
+	width := 0
+
+	for _, rh := range r.Hosts {
+		width = cmn.Max(width, fnWidth(rh.Host))
+	}
+
+	return width
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"testing"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+	"github.com/keewek/ansible-pretty-print/src/cmn/tst"
+	"github.com/keewek/ansible-pretty-print/src/processor"
+)
+
+func Test_runStatusSummary(t *testing.T) {
+	results := []*processor.HostResult{
+		{Host: "web01", Status: processor.StatusOk},
+		{Host: "web02", Status: processor.StatusChanged, Item: "git"},
+		{Host: "web03", Status: processor.StatusOk},
+	}
+
+	t.Run("task", func(t *testing.T) {
+		got := runStatusSummary(&processor.RunTask{Kind: processor.KindTask, Results: results})
+
+		tst.DiffError(t, "ok: web01, web03; changed: web02 (git)", got)
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		got := runStatusSummary(&processor.RunTask{Kind: processor.KindRunningHandler, Results: results[:1]})
+
+		tst.DiffError(t, "[handler] ok: web01", got)
+	})
+}
+
+func Test_recapLines(t *testing.T) {
+	recap := &processor.Recap{Hosts: []*processor.RecapHost{
+		{Host: "web01", Ok: 12, Changed: 3},
+		{Host: "db01", Ok: 1, Changed: 10, Ignored: 1},
+	}}
+
+	want := []string{
+		"ok=12  changed=3   unreachable=0  failed=0  skipped=0  rescued=0  ignored=0",
+		"ok=1   changed=10  unreachable=0  failed=0  skipped=0  rescued=0  ignored=1",
+	}
+
+	tst.DiffError(t, want, recapLines(recap))
+	tst.DiffError(t, 5, recapHostWidth(recap, cmn.WidthRunes))
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/keewek/ansible-pretty-print/src/cmn"
@@ -15,37 +16,50 @@ import (
 )
 
 type TablePrinter struct {
-	fnChopMarkLine func(line string, maxWidth int, chopMark string) string
-	widther        cmn.Widther
-	indentPlay     int
-	indentTask     int
-	padPlay        string
-	padTask        string
-	maxLineWidth   int
-	box            cmn.BoxChars
-}
-
-type tableWidth struct {
-	block int
-	name  int
-	tags  int
+	fnChopMarkLine     func(line string, maxWidth int, chopMark string) string
+	chopMark           string
+	widther            cmn.Widther
+	indentPlay         int
+	indentSection      int
+	indentTask         int
+	padPlay            string
+	padTask            string
+	maxLineWidth       int
+	box                cmn.BoxChars
+	theme              Theme
+	columnFit          map[string]TableColumn
+	taskColumns        []string
+	taskNumber         int              // Tasks printed so far, see ColumnNumber
+	allStats           *processor.Stats // Of the whole input being printed, see taskColumn.fnWidths
+	isAlignPlays       bool
+	isKeepIndent       bool
+	isWrapCells        bool
+	isKeepEmptyColumns bool
+	isPerPlayWidths    bool
+	isSeparateTables   bool // Tables set apart by blank lines, see PrintTo
+	isAfterTable       bool // A separated table awaits a blank line before further output
 }
 
 func NewTablePrinter() *TablePrinter {
 	return &TablePrinter{
 		fnChopMarkLine: cmn.ChopMarkLine,
+		chopMark:       cmn.ChopMarkDefault,
 		widther:        cmn.RunesWidther{},
 		indentPlay:     defaultIndentPlay,
+		indentSection:  defaultIndentSection,
 		indentTask:     defaultIndentTask,
 		padPlay:        strings.Repeat(" ", defaultIndentPlay),
 		padTask:        strings.Repeat(" ", defaultIndentTask),
 		box:            cmn.BoxCharsAscii(),
+		columnFit:      DefaultTableColumns(),
+		taskColumns:    DefaultTaskColumns(),
 	}
 }
 
 func (tp *TablePrinter) SetWidther(value cmn.Widther) *TablePrinter {
 	tp.widther = value
 	tp.fnChopMarkLine = cmn.ChopMarkLineSelector(value)
+	tp.chopMark = cmn.ChopMark(value)
 
 	return tp
 }
@@ -56,114 +70,604 @@ func (tp *TablePrinter) SetMaxLineWidth(value int) *TablePrinter {
 	return tp
 }
 
+func (tp *TablePrinter) SetIsAlignPlays(value bool) *TablePrinter {
+	tp.isAlignPlays = value
+
+	return tp
+}
+
+// SetIsKeepIndent makes the printer reproduce indentation detected in the input
+// instead of normalizing it
+func (tp *TablePrinter) SetIsKeepIndent(value bool) *TablePrinter {
+	tp.isKeepIndent = value
+
+	return tp
+}
+
+// SetIsWrapCells makes the printer wrap cells that exceed their fitted width onto additional
+// lines of the row instead of chopping them
+func (tp *TablePrinter) SetIsWrapCells(value bool) *TablePrinter {
+	tp.isWrapCells = value
+
+	return tp
+}
+
+// SetColumnFit sets how task table columns are fitted into the line width. Columns missing
+// from `value` keep their current fitting.
+func (tp *TablePrinter) SetColumnFit(value map[string]TableColumn) *TablePrinter {
+	for name, column := range value {
+		tp.columnFit[name] = column
+	}
+
+	return tp
+}
+
+// SetTaskColumns sets columns of the task table in order, see ParseTaskColumns. Unknown
+// columns are skipped.
+func (tp *TablePrinter) SetTaskColumns(value []string) *TablePrinter {
+	tp.taskColumns = make([]string, 0, len(value))
+
+	for _, name := range value {
+		if _, ok := taskColumns[name]; ok {
+			tp.taskColumns = append(tp.taskColumns, name)
+		}
+	}
+
+	return tp
+}
+
+// SetIsKeepEmptyColumns makes the printer draw every task column, even one that's empty for
+// every task of a table, so tables of all plays have the same columns
+func (tp *TablePrinter) SetIsKeepEmptyColumns(value bool) *TablePrinter {
+	tp.isKeepEmptyColumns = value
+
+	return tp
+}
+
+// SetIsPerPlayWidths makes the printer fit task tables of every play by Stats of the play
+// rather than of the whole input, so a long task name widens its own table only
+func (tp *TablePrinter) SetIsPerPlayWidths(value bool) *TablePrinter {
+	tp.isPerPlayWidths = value
+
+	return tp
+}
+
 func (tp *TablePrinter) SetBoxChars(value cmn.BoxChars) *TablePrinter {
 	tp.box = value
 
 	return tp
 }
 
-func (tp *TablePrinter) makeBorders(w *tableWidth) (top string, middle string, bottom string) {
+// SetTheme makes the printer color play headers, block and task names, tags and borders
+func (tp *TablePrinter) SetTheme(value Theme) *TablePrinter {
+	tp.theme = value
+
+	return tp
+}
+
+// cellLines returns lines of a cell `width` wide: value chopped or, if wrapping cells,
+// wrapped onto as many lines as needed
+func (tp *TablePrinter) cellLines(value string, width int) []string {
+	if tp.isWrapCells {
+		return cmn.WrapLineFunc(value, width, tp.widther.Width)
+	}
 
-	block := strings.Repeat(tp.box.Hor, w.block+2)
-	name := strings.Repeat(tp.box.Hor, w.name+2)
-	tags := strings.Repeat(tp.box.Hor, w.tags+2)
+	return []string{tp.fnChopMarkLine(value, width, tp.chopMark)}
+}
 
-	top = fmt.Sprint(tp.padTask, tp.box.CornerTL, block, tp.box.Top, name, tp.box.Top, tags, tp.box.CornerTR)
-	middle = fmt.Sprint(tp.padTask, tp.box.Left, block, tp.box.Cross, name, tp.box.Cross, tags, tp.box.Right)
-	bottom = fmt.Sprint(tp.padTask, tp.box.CornerBL, block, tp.box.Bottom, name, tp.box.Bottom, tags, tp.box.CornerBR)
+// printCells prints a table row of cells with the given widths, a line per line of its
+// tallest cell. `isRight` marks right-aligned cells; nil aligns every cell left. `styles`
+// are SGR parameters of cells, see Theme; nil leaves every cell as is.
+func (tp *TablePrinter) printCells(output io.Writer, pad string, cells []string, widths []int, isRight []bool, styles []string) {
+	lines := make([][]string, len(cells))
+	height := 1
+	ver := cmn.Sgr(tp.box.Ver, tp.theme.Border)
+
+	for i, cell := range cells {
+		lines[i] = tp.cellLines(cell, widths[i])
+		height = cmn.Max(height, len(lines[i]))
+	}
 
-	return top, middle, bottom
+	for l := 0; l < height; l++ {
+		var b strings.Builder
+
+		b.WriteString(pad)
+		b.WriteString(ver)
+
+		for i, w := range widths {
+			var value, cell string
+
+			if l < len(lines[i]) {
+				value = lines[i][l]
+			}
+
+			// Colored after chopping or wrapping, padded by visible width
+			if styles != nil {
+				value = cmn.Sgr(value, styles[i])
+			}
+
+			if isRight != nil && isRight[i] {
+				cell = cmn.PadLeftFunc(value, ' ', w, tp.widther.Width)
+			} else {
+				cell = cmn.PadRightFunc(value, ' ', w, tp.widther.Width)
+			}
+
+			fmt.Fprintf(&b, " %s %s", cell, ver)
+		}
+
+		tp.printLine(output, b.String())
+	}
 }
 
-func (tp *TablePrinter) fitTable(s *processor.Stats) *tableWidth {
-	width := &tableWidth{}
+// makeBorders returns borders of a table indented by `pad` with columns of the given widths
+func (tp *TablePrinter) makeBorders(pad string, widths []int) (top string, middle string, bottom string) {
+	fnBorder := func(left, middle, right string) string {
+		var b strings.Builder
 
-	fit := func(dW, width, minWidth int) (fit int, r int) {
-		fit = cmn.Max(minWidth, width-dW)
-		r = dW - (width - fit)
+		b.WriteString(pad)
+		b.WriteString(left)
 
-		return fit, r
+		for i, w := range widths {
+			if i > 0 {
+				b.WriteString(middle)
+			}
+
+			b.WriteString(strings.Repeat(tp.box.Hor, w+2))
+		}
+
+		b.WriteString(right)
+
+		return b.String()
 	}
 
-	minBlock := len("Block")
-	minName := len("Name")
-	minTags := len("Tags")
+	top = fnBorder(tp.box.CornerTL, tp.box.Top, tp.box.CornerTR)
+	middle = fnBorder(tp.box.Left, tp.box.Cross, tp.box.Right)
+	bottom = fnBorder(tp.box.CornerBL, tp.box.Bottom, tp.box.CornerBR)
 
-	width.block = cmn.Max(s.LongestTaskBlockLength, minBlock)
-	width.name = cmn.Max(s.LongestTaskNameLength, minName)
-	width.tags = cmn.Max(s.LongestTaskTagsLength, minTags)
+	return top, middle, bottom
+}
 
-	borderTop, _, _ := tp.makeBorders(width)
-	lineWidth := tp.widther.Width(borderTop)
+// maxCellWidth returns width of the widest cell of a single-column table indented by `indent`
+// that fits into `maxLineWidth`: indent + 2 borders + 2 paddings
+func (tp *TablePrinter) maxCellWidth(indent int) int {
+	return tp.maxLineWidth - indent - 4
+}
 
-	if lineWidth > tp.maxLineWidth {
+// tableColumns returns columns of the task table `t`: columns that are empty for every task
+// are dropped unless empty columns are kept. If every column is empty, all of them are kept.
+func (tp *TablePrinter) tableColumns(t *processor.Tasks) []string {
+	if tp.isKeepEmptyColumns {
+		return tp.taskColumns
+	}
 
-		dW := lineWidth - tp.maxLineWidth
-		width.tags, dW = fit(dW, width.tags, minTags)
+	columns := make([]string, 0, len(tp.taskColumns))
 
-		if dW > 0 {
-			width.name, dW = fit(dW, width.name, minName)
+	for _, name := range tp.taskColumns {
+		fnIsEmpty := taskColumns[name].fnIsEmpty
 
-			if dW > 0 {
-				width.block, _ = fit(dW, width.block, minBlock)
+		for _, task := range t.Tasks {
+			if fnIsEmpty == nil || !fnIsEmpty(task) {
+				columns = append(columns, name)
+				break
 			}
 		}
+	}
 
+	if len(columns) == 0 {
+		return tp.taskColumns
 	}
 
-	return width
+	return columns
+}
+
+// fitTable distributes the line width among the given task table columns, see fitColumns
+func (tp *TablePrinter) fitTable(names []string, s *processor.Stats) []int {
+	columns := make([]fitColumn, len(names))
+
+	for i, name := range names {
+		tc := taskColumns[name]
+		all := tp.allStats
+		if all == nil {
+			all = s
+		}
+
+		typical, longest := tc.fnWidths(s, all)
+		header := tp.widther.Width(tc.header)
+
+		if tc.isFixed {
+			header = cmn.Max(header, longest)
+		}
+
+		columns[i] = fitColumn{tp.columnFit[name], header, typical, longest}
+	}
+
+	available := tp.maxLineWidth - tp.gridTableWidth(make([]int, len(columns)))
+
+	return fitColumns(columns, available)
 }
 
 func (tp *TablePrinter) printTable(output io.Writer, t *processor.Tasks, s *processor.Stats) {
-	width := tp.fitTable(s)
-	borderTop, borderMiddle, borderBottom := tp.makeBorders(width)
+	names := tp.tableColumns(t)
+	widths := tp.fitTable(names, s)
+	borderTop, borderMiddle, borderBottom := tp.makeBorders(tp.padTask, widths)
+
+	header := make([]string, len(names))
+	isRight := make([]bool, len(names))
+	styles := make([]string, len(names))
+
+	for i, name := range names {
+		header[i] = taskColumns[name].header
+		isRight[i] = taskColumns[name].isRight
+		styles[i] = tp.theme.columnStyle(name)
+	}
+
+	fnPrintRow := func(r *taskRow) {
+		cells := make([]string, len(names))
+
+		for i, name := range names {
+			cells[i] = taskColumns[name].fnValue(r)
+		}
+
+		tp.printCells(output, tp.padTask, cells, widths, isRight, styles)
+	}
+
+	tp.openTable(output, borderTop)
+	tp.printCells(output, tp.padTask, header, widths, nil, nil)
+	tp.printBorder(output, borderMiddle, false)
+	for i, task := range t.Tasks {
+		tp.taskNumber++
+		fnPrintRow(&taskRow{task: task, play: t.PlayNumber, index: i + 1, number: tp.taskNumber})
+	}
+	tp.closeTable(output, borderBottom)
+}
 
-	fnPrintHeader := func() {
-		block := fmt.Sprintf("%-*s", width.block, "Block")
-		name := fmt.Sprintf("%-*s", width.name, "Name")
-		tags := fmt.Sprintf("%-*s", width.tags, "Tags")
+func (tp *TablePrinter) printTaskTagsTable(output io.Writer, t *processor.TaskTags) {
+	header := "Task tags"
+	width := tp.widther.Width(header)
 
-		tp.printLine(output, borderTop)
-		tp.printLine(output, fmt.Sprintf("%[1]s%[2]s %[3]s %[2]s %[4]s %[2]s %[5]s %[2]s", tp.padTask, tp.box.Ver, block, name, tags))
-		tp.printLine(output, borderMiddle)
+	for _, tag := range t.TagList {
+		width = cmn.Max(width, tp.widther.Width(tag))
 	}
 
-	fnPrintRow := func(t *processor.Task) {
-		block := cmn.PadLeftFunc(tp.fnChopMarkLine(t.Block, width.block, "▒"), ' ', width.block, tp.widther.Width)
-		name := cmn.PadRightFunc(tp.fnChopMarkLine(t.Name, width.name, "▒"), ' ', width.name, tp.widther.Width)
-		tags := cmn.PadRightFunc(tp.fnChopMarkLine(t.Tags, width.tags, "▒"), ' ', width.tags, tp.widther.Width)
+	width = cmn.Max(tp.widther.Width(header), cmn.Min(width, tp.maxCellWidth(tp.indentTask)))
+	borderTop, borderMiddle, borderBottom := tp.makeBorders(tp.padTask, []int{width})
 
-		tp.printLine(output, fmt.Sprintf("%[1]s%[2]s %[3]s %[2]s %[4]s %[2]s %[5]s %[2]s", tp.padTask, tp.box.Ver, block, name, tags))
+	fnPrintRow := func(value string, styles []string) {
+		tp.printCells(output, tp.padTask, []string{value}, []int{width}, nil, styles)
 	}
 
-	fnPrintHeader()
-	for _, task := range t.Tasks {
-		fnPrintRow(task)
+	tp.openTable(output, borderTop)
+	fnPrintRow(header, nil)
+	tp.printHeaderBorder(output, borderMiddle, len(t.TagList) > 0)
+	for _, tag := range t.TagList {
+		fnPrintRow(tag, []string{tp.theme.Tags})
 	}
-	tp.printLine(output, borderBottom)
+	tp.closeTable(output, borderBottom)
+}
+
+func (tp *TablePrinter) printHostsTable(output io.Writer, h *processor.Hosts) {
+	header := fmt.Sprintf("Hosts (%d)", h.Count)
+	width := tp.widther.Width(header)
+
+	lines := layoutGrid(h.Hosts, tp.maxCellWidth(tp.indentTask), defaultGridSeparator, tp.widther.Width)
+
+	for _, line := range lines {
+		width = cmn.Max(width, tp.widther.Width(line))
+	}
+
+	borderTop, borderMiddle, borderBottom := tp.makeBorders(tp.padTask, []int{width})
+
+	fnPrintRow := func(value string) {
+		cell := cmn.PadRightFunc(value, ' ', width, tp.widther.Width)
+
+		tp.printLine(output, fmt.Sprintf("%[1]s%[2]s %[3]s %[2]s", tp.padTask, cmn.Sgr(tp.box.Ver, tp.theme.Border), cell))
+	}
+
+	if h.Pattern != "" {
+		tp.printLine(output, strings.Repeat(" ", tp.indentSection)+hostsPatternLine(h))
+	}
+
+	tp.openTable(output, borderTop)
+	fnPrintRow(header)
+	tp.printHeaderBorder(output, borderMiddle, len(lines) > 0)
+	for _, line := range lines {
+		fnPrintRow(line)
+	}
+	tp.closeTable(output, borderBottom)
+}
+
+// gridTable is a bordered table of string cells, see printGridTable
+type gridTable struct {
+	header  []string
+	rows    [][]string
+	footer  []string // Optional last row separated by a border, e.g. totals
+	isRight []bool   // Right-aligned columns; nil aligns every column left
+}
+
+// gridTableWidth returns line width of a table with columns of the given widths
+func (tp *TablePrinter) gridTableWidth(widths []int) int {
+	// indent + borders + paddings
+	width := tp.indentTask + len(widths) + 1 + 2*len(widths)
+
+	for _, w := range widths {
+		width += w
+	}
+
+	return width
+}
+
+// printGridTable prints a bordered table with a header row. Columns are as wide as their
+// widest cell and shrink, last ones first but not below the header, to fit into `maxLineWidth`.
+func (tp *TablePrinter) printGridTable(output io.Writer, gt *gridTable) {
+	widths := make([]int, len(gt.header))
+	minWidths := make([]int, len(gt.header))
+
+	for i, cell := range gt.header {
+		minWidths[i] = tp.widther.Width(cell)
+		widths[i] = minWidths[i]
+	}
+
+	for _, row := range append(gt.rows, gt.footer) {
+		for i, cell := range row {
+			widths[i] = cmn.Max(widths[i], tp.widther.Width(cell))
+		}
+	}
+
+	lineWidth := tp.gridTableWidth(widths)
+
+	for i := len(widths) - 1; i >= 0 && lineWidth > tp.maxLineWidth; i-- {
+		fit := cmn.Max(minWidths[i], widths[i]-(lineWidth-tp.maxLineWidth))
+		lineWidth -= widths[i] - fit
+		widths[i] = fit
+	}
+
+	fnPrintRow := func(row []string) {
+		tp.printCells(output, tp.padTask, row, widths, gt.isRight, nil)
+	}
+
+	borderTop, borderMiddle, borderBottom := tp.makeBorders(tp.padTask, widths)
+
+	tp.openTable(output, borderTop)
+	fnPrintRow(gt.header)
+	tp.printBorder(output, borderMiddle, false)
+	for _, row := range gt.rows {
+		fnPrintRow(row)
+	}
+	if gt.footer != nil {
+		tp.printBorder(output, borderMiddle, false)
+		fnPrintRow(gt.footer)
+	}
+	tp.closeTable(output, borderBottom)
+}
+
+// printRunTaskTable prints host results of a run task. `Item` and `Details` columns are
+// shown only if any result has them.
+func (tp *TablePrinter) printRunTaskTable(output io.Writer, t *processor.RunTask) {
+	var isItem, isDetails bool
+
+	for _, hr := range t.Results {
+		isItem = isItem || hr.Item != ""
+		isDetails = isDetails || isRunResultDetailed(hr)
+	}
+
+	header := []string{"Host", "Status"}
+	if isItem {
+		header = append(header, "Item")
+	}
+	if isDetails {
+		header = append(header, "Details")
+	}
+
+	rows := make([][]string, 0, len(t.Results))
+
+	for _, hr := range t.Results {
+		row := []string{hr.Host, hr.Status}
+
+		if isItem {
+			row = append(row, hr.Item)
+		}
+
+		if isDetails {
+			if isRunResultDetailed(hr) {
+				row = append(row, hr.Message)
+			} else {
+				row = append(row, "")
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	line := strings.Repeat(" ", tp.indentSection) + t.String()
+	tp.printLine(output, line)
+
+	if len(rows) > 0 {
+		tp.printGridTable(output, &gridTable{header: header, rows: rows})
+	}
+}
+
+// printRecap prints `PLAY RECAP` as a table with a row per host and a row of totals.
+// Counter names are abbreviated if host names don't fit into `maxLineWidth` otherwise.
+func (tp *TablePrinter) printRecap(output io.Writer, r *processor.Recap) {
+	names, _ := recapCounters(r.Total())
+
+	header := append([]string{"Host"}, names...)
+	isRight := make([]bool, len(header))
+	widths := make([]int, len(header))
+
+	for i := range header {
+		isRight[i] = i > 0
+		widths[i] = tp.widther.Width(header[i])
+	}
+
+	widths[0] = cmn.Max(widths[0], recapHostWidth(r, tp.widther.Width))
+
+	if tp.gridTableWidth(widths) > tp.maxLineWidth {
+		header = append([]string{"Host"}, recapCounterAbbrs...)
+	}
+
+	fnRow := func(host string, rh *processor.RecapHost) []string {
+		_, values := recapCounters(rh)
+		row := []string{host}
+
+		for _, value := range values {
+			row = append(row, strconv.Itoa(value))
+		}
+
+		return row
+	}
+
+	rows := make([][]string, 0, len(r.Hosts))
+
+	for _, rh := range r.Hosts {
+		rows = append(rows, fnRow(rh.Host, rh))
+	}
+
+	tp.printLine(output, tp.padPlay+r.String())
+	tp.printGridTable(output, &gridTable{
+		header:  header,
+		rows:    rows,
+		footer:  fnRow("Total", r.Total()),
+		isRight: isRight,
+	})
 }
 
 func (tp *TablePrinter) printLine(output io.Writer, value string) {
-	fmt.Fprintln(output, tp.fnChopMarkLine(value, tp.maxLineWidth, "▒"))
+	tp.separateLine(output, value)
+	fmt.Fprintln(output, tp.fnChopMarkLine(value, tp.maxLineWidth, tp.chopMark))
+}
+
+// separateLine prints a blank line between a separated table and the line that follows it,
+// unless the line is blank itself
+func (tp *TablePrinter) separateLine(output io.Writer, line string) {
+	if tp.isAfterTable && strings.TrimSpace(cmn.StripAnsi(line)) != "" {
+		fmt.Fprintln(output)
+	}
+
+	tp.isAfterTable = false
+}
+
+// printHeaderBorder prints the border under the header of a table unless the table has no
+// rows, `isRows`, and the bottom border would double it. An open box has no bottom border and
+// keeps it: it's the delimiter row Markdown tables need.
+func (tp *TablePrinter) printHeaderBorder(output io.Writer, line string, isRows bool) {
+	if isRows || tp.box.IsOpen {
+		tp.printBorder(output, line, false)
+	}
+}
+
+// openTable prints the top border of a table, after a blank line if tables are separated
+func (tp *TablePrinter) openTable(output io.Writer, border string) {
+	if tp.isSeparateTables {
+		tp.isAfterTable = false
+		fmt.Fprintln(output)
+	}
+
+	tp.printBorder(output, border, true)
+}
+
+// closeTable prints the bottom border of a table, a blank line follows if tables are separated
+func (tp *TablePrinter) closeTable(output io.Writer, border string) {
+	tp.printBorder(output, border, true)
+	tp.isAfterTable = tp.isSeparateTables
+}
+
+// printBorder prints a border line unless the box doesn't draw it: a top or bottom one,
+// `isEdge`, of an open box or a blank one
+func (tp *TablePrinter) printBorder(output io.Writer, line string, isEdge bool) {
+	if (isEdge && tp.box.IsOpen) || cmn.IsBlankBorder(line) {
+		return
+	}
+
+	tp.printLine(output, sgrIndented(line, tp.theme.Border))
+}
+
+// isOpenLayout reports whether tables are of an open box but not laid out for it yet, see openLayout
+func (tp *TablePrinter) isOpenLayout() bool {
+	return tp.box.IsOpen && !tp.isSeparateTables
+}
+
+// openLayout returns a copy of tp laying out tables of an open box, e.g. Markdown ones.
+// Markdown renders lines indented by 4 spaces as code and runs adjacent tables together, so
+// tables aren't indented and are set apart by blank lines; sections are indented as plays.
+func (tp *TablePrinter) openLayout() *TablePrinter {
+	open := *tp
+	open.isSeparateTables = true
+	open.indentSection = open.indentPlay
+	open.indentTask = 0
+	open.padTask = ""
+
+	return &open
 }
 
 func (tp *TablePrinter) PrintTo(output io.Writer, data *processor.Result) {
 	var line string
 
+	if tp.isKeepIndent {
+		kept := *tp
+		kept.isKeepIndent = false
+		kept.indentPlay = keptIndent(data.Indents.Play, tp.indentPlay)
+		kept.indentSection = keptIndent(data.Indents.Section, tp.indentSection)
+		kept.indentTask = keptIndent(data.Indents.Item, tp.indentTask)
+		kept.padPlay = strings.Repeat(" ", kept.indentPlay)
+		kept.padTask = strings.Repeat(" ", kept.indentTask)
+
+		kept.PrintTo(output, data)
+		return
+	}
+
+	if tp.isOpenLayout() {
+		tp.openLayout().PrintTo(output, data)
+		return
+	}
+
 	padPlay := strings.Repeat(" ", tp.indentPlay)
+	fnStats := statsSelector(data, tp.isPerPlayWidths)
+	tp.taskNumber = 0
+	tp.allStats = data.Stats
+	tp.isAfterTable = false
 
 	for _, row := range data.Rows {
+		stats := fnStats(row)
 
 		switch t := row.Data.(type) {
 		case *processor.Play:
-			line = fmt.Sprintf("%s%s    TAGS: %s", padPlay, t.Description(), t.Tags)
+			header := t.Description()
+			if tp.isAlignPlays {
+				header = formatPlayHeader(t, data.Stats, true, tp.widther.Width)
+			}
+
+			line = fmt.Sprintf("%s%s    TAGS: %s", padPlay, cmn.Sgr(header, tp.theme.Play), cmn.Sgr(t.Tags, tp.theme.Tags))
 			tp.printLine(output, line)
 
 		case *processor.Tasks:
-			tp.printTable(output, t, data.Stats)
+			tp.printTable(output, t, stats)
+
+		case *processor.Hosts:
+			tp.printHostsTable(output, t)
+
+		case *processor.TaskTags:
+			tp.printTaskTagsTable(output, t)
+
+		case *processor.RunPlay:
+			tp.printLine(output, padPlay+cmn.Sgr(t.String(), tp.theme.Play))
+
+		case *processor.RunTask:
+			tp.printRunTaskTable(output, t)
+
+		case *processor.Recap:
+			tp.printRecap(output, t)
 
 		default:
-			tp.printLine(output, t.String())
+			line = formPassthru(row, data.Indents, tp.indentSection)
+
+			// Colored passthru is printed as is: escape sequences have no width and can't be chopped
+			if cmn.HasAnsi(line) {
+				tp.separateLine(output, line)
+				fmt.Fprintln(output, line)
+			} else {
+				tp.printLine(output, line)
+			}
 		}
 
 	}
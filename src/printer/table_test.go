@@ -17,12 +17,16 @@ import (
 func TestNewTablePrinter(t *testing.T) {
 	wtp := &TablePrinter{
 		fnChopMarkLine: cmn.ChopMarkLine,
+		chopMark:       cmn.ChopMarkDefault,
 		widther:        cmn.RunesWidther{},
 		indentPlay:     defaultIndentPlay,
+		indentSection:  defaultIndentSection,
 		indentTask:     defaultIndentTask,
 		padPlay:        strings.Repeat(" ", defaultIndentPlay),
 		padTask:        strings.Repeat(" ", defaultIndentTask),
 		box:            cmn.BoxCharsAscii(),
+		columnFit:      DefaultTableColumns(),
+		taskColumns:    DefaultTaskColumns(),
 	}
 
 	gtp := NewTablePrinter()
@@ -44,6 +48,12 @@ func Test_TablePrinterSetWidther(t *testing.T) {
 	got := fmt.Sprintf("%#v", tp.widther)
 
 	tst.DiffError(t, want, got)
+
+	t.Run("chop mark of ambiguous wide widther", func(t *testing.T) {
+		tp.SetWidther(cmn.AmbiguousWideWidther{})
+
+		tst.DiffError(t, cmn.ChopMarkAscii, tp.chopMark)
+	})
 }
 
 func Test_TablePrinterSetMaxLineWidth(t *testing.T) {
@@ -59,6 +69,41 @@ func Test_TablePrinterSetMaxLineWidth(t *testing.T) {
 	tst.DiffError(t, want, got)
 }
 
+func Test_TablePrinterSetIsAlignPlays(t *testing.T) {
+	tp := NewTablePrinter()
+	tp.SetIsAlignPlays(true)
+
+	tst.DiffError(t, true, tp.isAlignPlays)
+}
+
+func Test_TablePrinterSetIsKeepIndent(t *testing.T) {
+	tp := NewTablePrinter()
+	tp.SetIsKeepIndent(true)
+
+	tst.DiffError(t, true, tp.isKeepIndent)
+}
+
+func Test_TablePrinterSetIsWrapCells(t *testing.T) {
+	tp := NewTablePrinter()
+	tp.SetIsWrapCells(true)
+
+	tst.DiffError(t, true, tp.isWrapCells)
+}
+
+func Test_TablePrinterSetIsPerPlayWidths(t *testing.T) {
+	tp := NewTablePrinter()
+	tp.SetIsPerPlayWidths(true)
+
+	tst.DiffError(t, true, tp.isPerPlayWidths)
+}
+
+func Test_TablePrinterSetTheme(t *testing.T) {
+	tp := NewTablePrinter()
+	tp.SetTheme(ThemeColorsLight())
+
+	tst.DiffError(t, ThemeColorsLight(), tp.theme)
+}
+
 func Test_TablePrinterSetBoxChars(t *testing.T) {
 
 	w := cmn.BoxCharsDos()
@@ -80,25 +125,30 @@ func Test_TablePrinter_makeBorders(t *testing.T) {
 			top    string
 			middle string
 			bottom string
-			width  tableWidth
+			widths []int
 		}{
 			{
 				top:    "      +--+--+--+",
 				middle: "      +--+--+--+",
 				bottom: "      +--+--+--+",
-				width:  tableWidth{0, 0, 0}},
+				widths: []int{0, 0, 0}},
 			{
 				top:    "      +---+----+-----+",
 				middle: "      +---+----+-----+",
 				bottom: "      +---+----+-----+",
-				width:  tableWidth{1, 2, 3}},
+				widths: []int{1, 2, 3}},
+			{
+				top:    "      +---+",
+				middle: "      +---+",
+				bottom: "      +---+",
+				widths: []int{1}},
 		}
 
 		for _, tt := range tests {
 			t.Run("", func(t *testing.T) {
 				tp := NewTablePrinter()
 
-				top, middle, bottom := tp.makeBorders(&tt.width)
+				top, middle, bottom := tp.makeBorders(tp.padTask, tt.widths)
 
 				tst.DiffError(t, tt.top, top)
 				tst.DiffError(t, tt.middle, middle)
@@ -114,18 +164,18 @@ func Test_TablePrinter_makeBorders(t *testing.T) {
 			top    string
 			middle string
 			bottom string
-			width  tableWidth
+			widths []int
 		}{
 			{
 				top:    "      ┌──┬──┬──┐",
 				middle: "      ├──┼──┼──┤",
 				bottom: "      └──┴──┴──┘",
-				width:  tableWidth{0, 0, 0}},
+				widths: []int{0, 0, 0}},
 			{
 				top:    "      ┌───┬────┬─────┐",
 				middle: "      ├───┼────┼─────┤",
 				bottom: "      └───┴────┴─────┘",
-				width:  tableWidth{1, 2, 3}},
+				widths: []int{1, 2, 3}},
 		}
 
 		for _, tt := range tests {
@@ -133,7 +183,7 @@ func Test_TablePrinter_makeBorders(t *testing.T) {
 				tp := NewTablePrinter()
 				tp.SetBoxChars(cmn.BoxCharsDos())
 
-				top, middle, bottom := tp.makeBorders(&tt.width)
+				top, middle, bottom := tp.makeBorders(tp.padTask, tt.widths)
 
 				tst.DiffError(t, tt.top, top)
 				tst.DiffError(t, tt.middle, middle)
@@ -142,36 +192,77 @@ func Test_TablePrinter_makeBorders(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Pad", func(t *testing.T) {
+		top, middle, bottom := NewTablePrinter().makeBorders("  ", []int{3})
+
+		tst.DiffError(t, "  +-----+", top)
+		tst.DiffError(t, "  +-----+", middle)
+		tst.DiffError(t, "  +-----+", bottom)
+	})
 }
 
 func Test_TablePrinter_fitTable(t *testing.T) {
+	stats := &processor.Stats{
+		LongestTaskBlockLength: 10,
+		LongestTaskNameLength:  20,
+		LongestTaskTagsLength:  30,
+		TaskBlockLengthP90:     8,
+		TaskNameLengthP90:      16,
+		TaskTagsLengthP90:      12,
+	}
+
 	tests := []struct {
 		stats        *processor.Stats
+		taskColumns  []string
+		columnFit    map[string]TableColumn
 		maxLineWidth int
-		want         *tableWidth
+		want         []int
 	}{
 		{
 			stats:        &processor.Stats{},
 			maxLineWidth: 80,
-			want:         &tableWidth{block: 5, name: 4, tags: 4},
+			want:         []int{5, 4, 4},
 		},
 		{
-			stats: &processor.Stats{
-				LongestTaskBlockLength: 10,
-				LongestTaskNameLength:  20,
-				LongestTaskTagsLength:  30,
+			stats:        stats,
+			maxLineWidth: 80,
+			want:         []int{10, 20, 30},
+		},
+		{
+			stats:        stats,
+			maxLineWidth: 60,
+			want:         []int{8, 18, 18},
+		},
+		{
+			stats:        stats,
+			maxLineWidth: 40,
+			want:         []int{5, 12, 7},
+		},
+		{
+			stats:        stats,
+			maxLineWidth: 20,
+			want:         []int{5, 4, 4},
+		},
+		{
+			stats: stats,
+			columnFit: map[string]TableColumn{
+				ColumnName: {Weight: 1, Max: 12},
+				ColumnTags: {Weight: 0},
 			},
 			maxLineWidth: 60,
-			want:         &tableWidth{block: 10, name: 20, tags: 14},
+			want:         []int{8, 12, 24},
 		},
 		{
 			stats: &processor.Stats{
-				LongestTaskBlockLength: 10,
-				LongestTaskNameLength:  20,
-				LongestTaskTagsLength:  30,
+				LongestTaskNameLength: 20,
+				TaskNameLengthP90:     16,
+				MaxPlayTasksCount:     12,
+				MaxTaskLine:           1234,
 			},
+			taskColumns:  []string{ColumnIndex, ColumnName, ColumnLine},
 			maxLineWidth: 30,
-			want:         &tableWidth{block: 6, name: 4, tags: 4},
+			want:         []int{2, 8, 4},
 		},
 	}
 
@@ -179,13 +270,64 @@ func Test_TablePrinter_fitTable(t *testing.T) {
 		t.Run("", func(t *testing.T) {
 			tp := NewTablePrinter()
 			tp.SetMaxLineWidth(tt.maxLineWidth)
+			tp.SetColumnFit(tt.columnFit)
+
+			if tt.taskColumns != nil {
+				tp.SetTaskColumns(tt.taskColumns)
+			}
+
+			tst.DiffError(t, tt.want, tp.fitTable(tp.taskColumns, tt.stats))
+		})
+	}
+}
 
-			tw := tp.fitTable(tt.stats)
+func Test_TablePrinter_fitTable_allStats(t *testing.T) {
+	play := &processor.Stats{TasksCount: 2, MaxPlayTasksCount: 2}
 
-			want := tst.ReprValue(tt.want) // fmt.Sprintf("%#v", tt.want)
-			got := tst.ReprValue(tw)       // fmt.Sprintf("%#v", tw)
+	tp := NewTablePrinter().SetMaxLineWidth(80).SetTaskColumns([]string{ColumnIndex, ColumnNumber})
+	tp.allStats = &processor.Stats{TasksCount: 100, MaxPlayTasksCount: 80}
 
-			tst.DiffError(t, want, got)
+	// Task numbers run across plays, indexes don't
+	tst.DiffError(t, []int{1, 3}, tp.fitTable(tp.taskColumns, play))
+}
+
+func Test_TablePrinter_tableColumns(t *testing.T) {
+	tests := []struct {
+		tasks   []*processor.Task
+		columns []string
+		want    []string
+	}{
+		{
+			tasks: []*processor.Task{{Name: "Task", Tags: "[]"}, {Name: "Task", Tags: "[a]"}},
+			want:  []string{ColumnName, ColumnTags},
+		},
+		{
+			tasks: []*processor.Task{{Block: "role", Name: "Task", Tags: "[]"}},
+			want:  []string{ColumnBlock, ColumnName},
+		},
+		{
+			tasks:   []*processor.Task{{Name: "Task", Tags: "[]"}},
+			columns: []string{ColumnTags, ColumnLine},
+			want:    []string{ColumnLine},
+		},
+		{
+			tasks:   []*processor.Task{{Name: "Task", Tags: "[]"}},
+			columns: []string{ColumnBlock, ColumnTags},
+			want:    []string{ColumnBlock, ColumnTags},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			tp := NewTablePrinter()
+
+			if tt.columns != nil {
+				tp.SetTaskColumns(tt.columns)
+			}
+
+			got := tp.tableColumns(&processor.Tasks{PlayNumber: 1, Tasks: tt.tasks})
+
+			tst.DiffError(t, tt.want, got)
 		})
 	}
 }
@@ -329,12 +471,12 @@ func Test_TablePrinter_printTable(t *testing.T) {
 			}
 
 			maxLineWidth := 40
-			want.WriteLine("      +------------+------------+------+")
-			want.WriteLine("      | Block      | Name       | Tags |")
-			want.WriteLine("      +------------+------------+------+")
-			want.WriteLine("      | 0123456789 | 0123456789 | 012▒ |")
-			want.WriteLine("      | ABCDEFGHIJ | ABCDEFGHIJ | ABC▒ |")
-			want.WriteLine("      +------------+------------+------+")
+			want.WriteLine("      +---------+----------+-----------+")
+			want.WriteLine("      | Block   | Name     | Tags      |")
+			want.WriteLine("      +---------+----------+-----------+")
+			want.WriteLine("      | 012345▒ | 0123456▒ | 01234567▒ |")
+			want.WriteLine("      | ABCDEF▒ | ABCDEFG▒ | ABCDEFGH▒ |")
+			want.WriteLine("      +---------+----------+-----------+")
 
 			tp := NewTablePrinter()
 			tp.SetMaxLineWidth(maxLineWidth)
@@ -369,12 +511,12 @@ func Test_TablePrinter_printTable(t *testing.T) {
 			}
 
 			maxLineWidth := 30
-			want.WriteLine("      +--------+------+------+")
-			want.WriteLine("      | Block  | Name | Tags |")
-			want.WriteLine("      +--------+------+------+")
-			want.WriteLine("      | 01234▒ | 012▒ | 012▒ |")
-			want.WriteLine("      | ABCDE▒ | ABC▒ | ABC▒ |")
-			want.WriteLine("      +--------+------+------+")
+			want.WriteLine("      +-------+-------+------+")
+			want.WriteLine("      | Block | Name  | Tags |")
+			want.WriteLine("      +-------+-------+------+")
+			want.WriteLine("      | 0123▒ | 0123▒ | 012▒ |")
+			want.WriteLine("      | ABCD▒ | ABCD▒ | ABC▒ |")
+			want.WriteLine("      +-------+-------+------+")
 
 			tp := NewTablePrinter()
 			tp.SetMaxLineWidth(maxLineWidth)
@@ -499,12 +641,12 @@ func Test_TablePrinter_printTable(t *testing.T) {
 			}
 
 			maxLineWidth := 40
-			want.WriteLine("      ┌────────────┬────────────┬──────┐")
-			want.WriteLine("      │ Block      │ Name       │ Tags │")
-			want.WriteLine("      ├────────────┼────────────┼──────┤")
-			want.WriteLine("      │ 0123456789 │ 0123456789 │ 012▒ │")
-			want.WriteLine("      │ ABCDEFGHIJ │ ABCDEFGHIJ │ ABC▒ │")
-			want.WriteLine("      └────────────┴────────────┴──────┘")
+			want.WriteLine("      ┌─────────┬──────────┬───────────┐")
+			want.WriteLine("      │ Block   │ Name     │ Tags      │")
+			want.WriteLine("      ├─────────┼──────────┼───────────┤")
+			want.WriteLine("      │ 012345▒ │ 0123456▒ │ 01234567▒ │")
+			want.WriteLine("      │ ABCDEF▒ │ ABCDEFG▒ │ ABCDEFGH▒ │")
+			want.WriteLine("      └─────────┴──────────┴───────────┘")
 
 			tp := NewTablePrinter()
 			tp.SetMaxLineWidth(maxLineWidth)
@@ -540,12 +682,12 @@ func Test_TablePrinter_printTable(t *testing.T) {
 			}
 
 			maxLineWidth := 30
-			want.WriteLine("      ┌────────┬──────┬──────┐")
-			want.WriteLine("      │ Block  │ Name │ Tags │")
-			want.WriteLine("      ├────────┼──────┼──────┤")
-			want.WriteLine("      │ 01234▒ │ 012▒ │ 012▒ │")
-			want.WriteLine("      │ ABCDE▒ │ ABC▒ │ ABC▒ │")
-			want.WriteLine("      └────────┴──────┴──────┘")
+			want.WriteLine("      ┌───────┬───────┬──────┐")
+			want.WriteLine("      │ Block │ Name  │ Tags │")
+			want.WriteLine("      ├───────┼───────┼──────┤")
+			want.WriteLine("      │ 0123▒ │ 0123▒ │ 012▒ │")
+			want.WriteLine("      │ ABCD▒ │ ABCD▒ │ ABC▒ │")
+			want.WriteLine("      └───────┴───────┴──────┘")
 
 			tp := NewTablePrinter()
 			tp.SetMaxLineWidth(maxLineWidth)
@@ -558,6 +700,156 @@ func Test_TablePrinter_printTable(t *testing.T) {
 
 }
 
+func Test_TablePrinter_printTaskTagsTable(t *testing.T) {
+	tests := []struct {
+		name         string
+		taskTags     *processor.TaskTags
+		maxLineWidth int
+		want         []string
+	}{
+		{
+			name:         "no tags",
+			taskTags:     &processor.TaskTags{PlayNumber: 1, Tags: "[]"},
+			maxLineWidth: 80,
+			want: []string{
+				"      +-----------+",
+				"      | Task tags |",
+				"      +-----------+",
+			},
+		},
+		{
+			name:         "tags",
+			taskTags:     &processor.TaskTags{PlayNumber: 1, Tags: "[apt, bootstrap-apt-long]", TagList: []string{"apt", "bootstrap-apt-long"}},
+			maxLineWidth: 80,
+			want: []string{
+				"      +--------------------+",
+				"      | Task tags          |",
+				"      +--------------------+",
+				"      | apt                |",
+				"      | bootstrap-apt-long |",
+				"      +--------------------+",
+			},
+		},
+		{
+			name:         "chopped tags",
+			taskTags:     &processor.TaskTags{PlayNumber: 1, Tags: "[apt, bootstrap-apt-long]", TagList: []string{"apt", "bootstrap-apt-long"}},
+			maxLineWidth: 22,
+			want: []string{
+				"      +--------------+",
+				"      | Task tags    |",
+				"      +--------------+",
+				"      | apt          |",
+				"      | bootstrap-a▒ |",
+				"      +--------------+",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, want cmn.LineBuilder
+
+			for _, line := range tt.want {
+				want.WriteLine(line)
+			}
+
+			tp := NewTablePrinter()
+			tp.SetMaxLineWidth(tt.maxLineWidth)
+			tp.printTaskTagsTable(&out, tt.taskTags)
+
+			tst.DiffError(t, want.String(), out.String())
+		})
+	}
+}
+
+func Test_TablePrinter_printCells(t *testing.T) {
+	cells := []string{"nginx", "Configure nginx for the site", "[nginx, web]"}
+	widths := []int{5, 10, 6}
+	isRight := []bool{true, false, false}
+
+	t.Run("chop", func(t *testing.T) {
+		var out, want cmn.LineBuilder
+		want.WriteLine("  | nginx | Configure▒ | [ngin▒ |")
+
+		tp := NewTablePrinter().SetMaxLineWidth(80)
+		tp.printCells(&out, "  ", cells, widths, isRight, nil)
+
+		tst.DiffError(t, want.String(), out.String())
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		var out, want cmn.LineBuilder
+		want.WriteLine("  | nginx | Configure  | [nginx |")
+		want.WriteLine("  |       | nginx for  | , web] |")
+		want.WriteLine("  |       | the site   |        |")
+
+		tp := NewTablePrinter().SetMaxLineWidth(80).SetIsWrapCells(true)
+		tp.printCells(&out, "  ", cells, widths, isRight, nil)
+
+		tst.DiffError(t, want.String(), out.String())
+	})
+}
+
+func Test_TablePrinter_printRecap(t *testing.T) {
+	recap := &processor.Recap{Hosts: []*processor.RecapHost{
+		{Host: "web01", Ok: 12, Changed: 3},
+		{Host: "db01", Ok: 1, Unreachable: 1},
+	}}
+
+	tests := []struct {
+		name         string
+		maxLineWidth int
+		want         []string
+	}{
+		{
+			name:         "full counter names",
+			maxLineWidth: 100,
+			want: []string{
+				"  PLAY RECAP",
+				"      +-------+----+---------+-------------+--------+---------+---------+---------+",
+				"      | Host  | ok | changed | unreachable | failed | skipped | rescued | ignored |",
+				"      +-------+----+---------+-------------+--------+---------+---------+---------+",
+				"      | web01 | 12 |       3 |           0 |      0 |       0 |       0 |       0 |",
+				"      | db01  |  1 |       0 |           1 |      0 |       0 |       0 |       0 |",
+				"      +-------+----+---------+-------------+--------+---------+---------+---------+",
+				"      | Total | 13 |       3 |           1 |      0 |       0 |       0 |       0 |",
+				"      +-------+----+---------+-------------+--------+---------+---------+---------+",
+			},
+		},
+		{
+			name:         "abbreviated counter names",
+			maxLineWidth: 80,
+			want: []string{
+				"  PLAY RECAP",
+				"      +-------+----+-----+-----+------+------+------+-----+",
+				"      | Host  | ok | chg | unr | fail | skip | resc | ign |",
+				"      +-------+----+-----+-----+------+------+------+-----+",
+				"      | web01 | 12 |   3 |   0 |    0 |    0 |    0 |   0 |",
+				"      | db01  |  1 |   0 |   1 |    0 |    0 |    0 |   0 |",
+				"      +-------+----+-----+-----+------+------+------+-----+",
+				"      | Total | 13 |   3 |   1 |    0 |    0 |    0 |   0 |",
+				"      +-------+----+-----+-----+------+------+------+-----+",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, want cmn.LineBuilder
+
+			for _, line := range tt.want {
+				want.WriteLine(line)
+			}
+
+			tp := NewTablePrinter()
+			tp.SetMaxLineWidth(tt.maxLineWidth)
+			tp.printRecap(&out, recap)
+
+			tst.DiffError(t, want.String(), out.String())
+		})
+	}
+}
+
 func Test_TablePrinterPrintTo(t *testing.T) {
 
 	t.Run("row is fmt.Stringer", func(t *testing.T) {
@@ -713,12 +1005,12 @@ func Test_TablePrinterPrintTo(t *testing.T) {
 			}
 
 			var lb, out cmn.LineBuilder
-			lb.WriteLine("      +--------+------+------+")
-			lb.WriteLine("      | Block  | Name | Tags |")
-			lb.WriteLine("      +--------+------+------+")
-			lb.WriteLine("      | 01234▒ | 012▒ | 012▒ |")
-			lb.WriteLine("      | ABCDE▒ | ABC▒ | ABC▒ |")
-			lb.WriteLine("      +--------+------+------+")
+			lb.WriteLine("      +-------+-------+------+")
+			lb.WriteLine("      | Block | Name  | Tags |")
+			lb.WriteLine("      +-------+-------+------+")
+			lb.WriteLine("      | 0123▒ | 0123▒ | 012▒ |")
+			lb.WriteLine("      | ABCD▒ | ABCD▒ | ABC▒ |")
+			lb.WriteLine("      +-------+-------+------+")
 
 			tp := NewTablePrinter()
 			tp.SetMaxLineWidth(30)
@@ -729,6 +1021,97 @@ func Test_TablePrinterPrintTo(t *testing.T) {
 
 			tst.DiffError(t, want, got)
 		})
+		t.Run("task columns", func(t *testing.T) {
+			r := processor.Result{
+				Rows: []*processor.Row{
+					{Indent: 0, Data: &processor.Tasks{
+						PlayNumber: 1,
+						Tasks: []*processor.Task{
+							{Name: "Task 1.1", Tags: "[a, b]", TagList: []string{"a", "b"}, Line: 5},
+							{Name: "Task 1.2", Tags: "[]", Line: 6},
+						},
+					}},
+					{Indent: 0, Data: &processor.Tasks{
+						PlayNumber: 2,
+						Tasks: []*processor.Task{
+							{Name: "Task 2.1", Tags: "[a]", TagList: []string{"a"}, Line: 10},
+						},
+					}},
+				},
+				Stats: &processor.Stats{
+					LongestTaskNameLength: 8,
+					MaxPlayNumber:         2,
+					TasksCount:            3,
+					MaxPlayTasksCount:     2,
+					MaxTaskTagsCount:      2,
+					MaxTaskLine:           10,
+				},
+			}
+
+			var lb, out cmn.LineBuilder
+			lb.WriteLine("      +----+------+---+----------+-------+------+")
+			lb.WriteLine("      | No | Play | # | Name     | #Tags | Line |")
+			lb.WriteLine("      +----+------+---+----------+-------+------+")
+			lb.WriteLine("      |  1 |    1 | 1 | Task 1.1 |     2 |    5 |")
+			lb.WriteLine("      |  2 |    1 | 2 | Task 1.2 |     0 |    6 |")
+			lb.WriteLine("      +----+------+---+----------+-------+------+")
+			lb.WriteLine("      +----+------+---+----------+-------+------+")
+			lb.WriteLine("      | No | Play | # | Name     | #Tags | Line |")
+			lb.WriteLine("      +----+------+---+----------+-------+------+")
+			lb.WriteLine("      |  3 |    2 | 1 | Task 2.1 |     1 |   10 |")
+			lb.WriteLine("      +----+------+---+----------+-------+------+")
+
+			tp := NewTablePrinter()
+			tp.SetMaxLineWidth(80)
+			tp.SetTaskColumns([]string{ColumnNumber, ColumnPlay, ColumnIndex, ColumnName, ColumnTagCount, ColumnLine})
+			tp.PrintTo(&out, &r)
+
+			tst.DiffError(t, lb.String(), out.String())
+		})
+		t.Run("empty columns", func(t *testing.T) {
+			r := processor.Result{
+				Rows: []*processor.Row{
+					{Indent: 0, Data: &processor.Tasks{
+						PlayNumber: 1,
+						Tasks: []*processor.Task{
+							{Name: "Task 1.1", Tags: "[]"},
+							{Name: "Task 1.2", Tags: "[]"},
+						},
+					}},
+				},
+				Stats: &processor.Stats{
+					LongestTaskNameLength: 8,
+					LongestTaskTagsLength: 2,
+				},
+			}
+
+			for _, isKeep := range []bool{false, true} {
+				var lb, out cmn.LineBuilder
+
+				if isKeep {
+					lb.WriteLine("      +-------+----------+------+")
+					lb.WriteLine("      | Block | Name     | Tags |")
+					lb.WriteLine("      +-------+----------+------+")
+					lb.WriteLine("      |       | Task 1.1 | []   |")
+					lb.WriteLine("      |       | Task 1.2 | []   |")
+					lb.WriteLine("      +-------+----------+------+")
+				} else {
+					lb.WriteLine("      +----------+")
+					lb.WriteLine("      | Name     |")
+					lb.WriteLine("      +----------+")
+					lb.WriteLine("      | Task 1.1 |")
+					lb.WriteLine("      | Task 1.2 |")
+					lb.WriteLine("      +----------+")
+				}
+
+				tp := NewTablePrinter()
+				tp.SetMaxLineWidth(80)
+				tp.SetIsKeepEmptyColumns(isKeep)
+				tp.PrintTo(&out, &r)
+
+				tst.DiffError(t, lb.String(), out.String())
+			}
+		})
 	})
 
 	t.Run("mixed rows", func(t *testing.T) {
@@ -757,12 +1140,12 @@ func Test_TablePrinterPrintTo(t *testing.T) {
 		lb.WriteLine("Passthru")
 		lb.WriteLine("  play #1 (demo): Demo play  ▒")
 		lb.WriteLine("|   blockquote")
-		lb.WriteLine("      +--------+------+------+")
-		lb.WriteLine("      | Block  | Name | Tags |")
-		lb.WriteLine("      +--------+------+------+")
-		lb.WriteLine("      | 01234▒ | 012▒ | 012▒ |")
-		lb.WriteLine("      | ABCDE▒ | ABC▒ | ABC▒ |")
-		lb.WriteLine("      +--------+------+------+")
+		lb.WriteLine("      +-------+-------+------+")
+		lb.WriteLine("      | Block | Name  | Tags |")
+		lb.WriteLine("      +-------+-------+------+")
+		lb.WriteLine("      | 0123▒ | 0123▒ | 012▒ |")
+		lb.WriteLine("      | ABCD▒ | ABCD▒ | ABC▒ |")
+		lb.WriteLine("      +-------+-------+------+")
 
 		tp := NewTablePrinter()
 		tp.SetMaxLineWidth(30)
@@ -776,3 +1159,129 @@ func Test_TablePrinterPrintTo(t *testing.T) {
 	})
 
 }
+
+func Test_TablePrinterPrintTo_perPlayWidths(t *testing.T) {
+	data := processLines(t,
+		"  play #1 (web): Web	TAGS: []",
+		"    tasks:",
+		"      Task 1.1	TAGS: [web]",
+		"  play #2 (db): Databases	TAGS: []",
+		"    tasks:",
+		"      A task with a long name	TAGS: [db]",
+	)
+
+	var lb, out cmn.LineBuilder
+	lb.WriteLine("  play #1 (web): Web    TAGS: []")
+	lb.WriteLine("    tasks:")
+	lb.WriteLine("      +----------+-------+")
+	lb.WriteLine("      | Name     | Tags  |")
+	lb.WriteLine("      +----------+-------+")
+	lb.WriteLine("      | Task 1.1 | [web] |")
+	lb.WriteLine("      +----------+-------+")
+	lb.WriteLine("  play #2 (db): Databases    TAGS: []")
+	lb.WriteLine("    tasks:")
+	lb.WriteLine("      +-------------------------+------+")
+	lb.WriteLine("      | Name                    | Tags |")
+	lb.WriteLine("      +-------------------------+------+")
+	lb.WriteLine("      | A task with a long name | [db] |")
+	lb.WriteLine("      +-------------------------+------+")
+
+	tp := NewTablePrinter().SetIsPerPlayWidths(true).SetMaxLineWidth(80)
+	tp.PrintTo(&out, data)
+
+	tst.DiffError(t, lb.String(), out.String())
+}
+
+func Test_TablePrinter_printTable_boxStyles(t *testing.T) {
+	tasks := &processor.Tasks{
+		PlayNumber: 1,
+		Tasks:      []*processor.Task{{Name: "Ping", Tags: "[net]"}},
+	}
+
+	stats := &processor.Stats{LongestTaskNameLength: 4, LongestTaskTagsLength: 5}
+
+	t.Run("markdown", func(t *testing.T) {
+		var out, want cmn.LineBuilder
+		want.WriteLine("      | Name | Tags  |")
+		want.WriteLine("      |------|-------|")
+		want.WriteLine("      | Ping | [net] |")
+
+		tp := NewTablePrinter().SetMaxLineWidth(80).SetBoxChars(cmn.BoxCharsMarkdown())
+		tp.printTable(&out, tasks, stats)
+
+		tst.DiffError(t, want.String(), out.String())
+	})
+
+	t.Run("borderless", func(t *testing.T) {
+		var out, want cmn.LineBuilder
+		want.WriteLine("        Name   Tags   ")
+		want.WriteLine("        Ping   [net]  ")
+
+		tp := NewTablePrinter().SetMaxLineWidth(80).SetBoxChars(cmn.BoxCharsBorderless())
+		tp.printTable(&out, tasks, stats)
+
+		tst.DiffError(t, want.String(), out.String())
+	})
+}
+
+func Test_TablePrinterPrintTo_openBox(t *testing.T) {
+	data := processLines(t,
+		"  play #1 (web): Web	TAGS: [web]",
+		"    tasks:",
+		"      Ping	TAGS: [net]",
+		"    TASK TAGS: [net]",
+		"",
+		"  play #2 (db): Databases	TAGS: []",
+		"    tasks:",
+		"      Query	TAGS: []",
+	)
+
+	var lb, out cmn.LineBuilder
+	lb.WriteLine("  play #1 (web): Web    TAGS: [web]")
+	lb.WriteLine("  tasks:")
+	lb.WriteLine("")
+	lb.WriteLine("| Name  | Tags  |")
+	lb.WriteLine("|-------|-------|")
+	lb.WriteLine("| Ping  | [net] |")
+	lb.WriteLine("")
+	lb.WriteLine("| Task tags |")
+	lb.WriteLine("|-----------|")
+	lb.WriteLine("| net       |")
+	lb.WriteLine("")
+	lb.WriteLine("  play #2 (db): Databases    TAGS: []")
+	lb.WriteLine("  tasks:")
+	lb.WriteLine("")
+	lb.WriteLine("| Name  |")
+	lb.WriteLine("|-------|")
+	lb.WriteLine("| Query |")
+
+	tp := NewTablePrinter().SetMaxLineWidth(80).SetBoxChars(cmn.BoxCharsMarkdown())
+	tp.PrintTo(&out, data)
+
+	tst.DiffError(t, lb.String(), out.String())
+}
+
+func Test_TablePrinterPrintTo_theme(t *testing.T) {
+	data := processLines(t,
+		"  play #1 (web): Web	TAGS: [web]",
+		"    tasks:",
+		"      Ping	TAGS: [net]",
+	)
+
+	theme := Theme{Play: "1", Name: "2", Tags: "32", Border: "90"}
+	ver := cmn.Sgr("|", "90")
+
+	var lb, out cmn.LineBuilder
+	lb.WriteLine("  " + cmn.Sgr("play #1 (web): Web", "1") + "    TAGS: " + cmn.Sgr("[web]", "32"))
+	lb.WriteLine("    tasks:")
+	lb.WriteLine("      " + cmn.Sgr("+------+-------+", "90"))
+	lb.WriteLine("      " + ver + " Name " + ver + " Tags  " + ver)
+	lb.WriteLine("      " + cmn.Sgr("+------+-------+", "90"))
+	lb.WriteLine("      " + ver + " " + cmn.Sgr("Ping", "2") + " " + ver + " " + cmn.Sgr("[net]", "32") + " " + ver)
+	lb.WriteLine("      " + cmn.Sgr("+------+-------+", "90"))
+
+	tp := NewTablePrinter().SetTheme(theme).SetMaxLineWidth(80)
+	tp.PrintTo(&out, data)
+
+	tst.DiffError(t, lb.String(), out.String())
+}
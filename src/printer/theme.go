@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package printer
+
+import (
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+)
+
+// Built-in themes, see ThemeByName
+const (
+	ThemeDark  = "dark"  // For dark terminal backgrounds
+	ThemeLight = "light" // For light terminal backgrounds
+)
+
+// Theme colors output elements with SGR parameters, e.g. `1;34` for bold blue. An empty
+// style leaves its element as is, so zero Theme is monochrome.
+type Theme struct {
+	Play   string // Play headers
+	Block  string // Block and role names
+	Name   string // Task names
+	Tags   string // Tags of plays and tasks
+	Border string // Table borders
+}
+
+func ThemeColorsDark() Theme {
+	return Theme{
+		Play:   "1;36",
+		Block:  "33",
+		Name:   "1",
+		Tags:   "32",
+		Border: "90",
+	}
+}
+
+func ThemeColorsLight() Theme {
+	return Theme{
+		Play:   "1;34",
+		Block:  "35",
+		Name:   "1",
+		Tags:   "32",
+		Border: "90",
+	}
+}
+
+// ThemeByName returns colors of a built-in theme
+func ThemeByName(name string) (Theme, bool) {
+	switch name {
+	case ThemeDark:
+		return ThemeColorsDark(), true
+	case ThemeLight:
+		return ThemeColorsLight(), true
+	}
+
+	return Theme{}, false
+}
+
+// sgrIndented renders s with SGR parameters `params` leaving its indentation as is
+func sgrIndented(s string, params string) string {
+	text := strings.TrimLeft(s, " ")
+
+	return s[:len(s)-len(text)] + cmn.Sgr(text, params)
+}
+
+// columnStyle returns style of a task table column, see taskColumns
+func (th Theme) columnStyle(name string) string {
+	switch name {
+	case ColumnBlock:
+		return th.Block
+	case ColumnName:
+		return th.Name
+	case ColumnTags:
+		return th.Tags
+	}
+
+	return ""
+}
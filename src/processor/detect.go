@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+)
+
+// Lines to peek at before falling back to FormatList
+const detectPeekLines = 100
+
+// DetectFormat guesses input format by its first significant line. Blank lines and lines
+// that any format may start with, e.g. warnings, are skipped. `ok` is false if no line
+// is significant; FormatList is returned then.
+func DetectFormat(lines []string) (format string, ok bool) {
+	for _, line := range lines {
+		text := strings.TrimSpace(cmn.StripAnsi(line))
+
+		switch {
+		case text == "":
+			continue
+
+		case strings.HasPrefix(text, "{"):
+			return FormatJson, true
+
+		case strings.HasPrefix(text, "playbook: "), strings.HasPrefix(text, "play #"):
+			return FormatList, true
+		}
+
+		if _, _, ok := processRunHeader(text); ok {
+			return FormatRun, true
+		}
+	}
+
+	return FormatList, false
+}
+
+// replayReader yields lines already read from a scanner followed by the rest of its lines
+type replayReader struct {
+	scanner *bufio.Scanner
+	buf     bytes.Buffer
+}
+
+func (r *replayReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				return 0, err
+			}
+
+			return 0, io.EOF
+		}
+
+		r.buf.WriteString(r.scanner.Text())
+		r.buf.WriteByte('\n')
+	}
+
+	return r.buf.Read(p)
+}
+
+// peekFormat reads lines until the format is detected. Returned scanner yields input from
+// the very beginning.
+func peekFormat(scanner *bufio.Scanner) (string, *bufio.Scanner) {
+	replay := &replayReader{scanner: scanner}
+	lines := make([]string, 0, 1)
+
+	// JSON documents may have long lines
+	scanner.Buffer(nil, maxJsonLineSize)
+
+	for len(lines) < detectPeekLines && scanner.Scan() {
+		line := scanner.Text()
+		lines = append(lines, line)
+
+		replay.buf.WriteString(line)
+		replay.buf.WriteByte('\n')
+
+		if _, ok := DetectFormat(lines[len(lines)-1:]); ok {
+			break
+		}
+	}
+
+	format, _ := DetectFormat(lines)
+
+	return format, bufio.NewScanner(replay)
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDetectFormat(t *testing.T) {
+	type result struct {
+		Format string
+		Ok     bool
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  result
+	}{
+		{"list", []string{"", "playbook: site.yml"}, result{FormatList, true}},
+		{"list, no playbook line", []string{"  play #1 (all): Demo\tTAGS: []"}, result{FormatList, true}},
+		{"run", []string{"", "PLAY [Demo] ****"}, result{FormatRun, true}},
+		{"run, task first", []string{"TASK [Ping] ****"}, result{FormatRun, true}},
+		{"json after warning", []string{"[WARNING]: something", "{"}, result{FormatJson, true}},
+		{"unknown", []string{"", "[WARNING]: something"}, result{FormatList, false}},
+		{"empty", nil, result{FormatList, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got result
+
+			got.Format, got.Ok = DetectFormat(tt.lines)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProcessor_ProcessAuto(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		format string
+		rows   int
+	}{
+		{"list", "\nplaybook: site.yml\n\n  play #1 (all): Demo\tTAGS: []\n", FormatList, 4},
+		{"run", "[WARNING]: something\n\nPLAY [Demo] ****\n\nTASK [Ping] ****\nok: [h1]\n", FormatRun, 5},
+		{"json", "{\"plays\": []}\n", FormatJson, 0},
+		{"undetected", "\n\n", FormatList, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+
+			got, err := NewProcessor().SetFormat(FormatAuto).Process(scanner)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.format, got.Stats.InputFormat); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+
+			// Peeked lines are processed as well
+			if diff := cmp.Diff(tt.rows, len(got.Rows)); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PlayFilter selects plays by their header fields. Zero value matches every play.
+type PlayFilter struct {
+	Number      int    // Play number; 0 matches any
+	HostPattern string // Shell glob matched against the whole host pattern, see path.Match
+	Title       string // Case-insensitive substring of the play title
+}
+
+func (f PlayFilter) IsEmpty() bool {
+	return f == PlayFilter{}
+}
+
+func (f PlayFilter) Validate() error {
+	if _, err := path.Match(f.HostPattern, ""); err != nil {
+		return fmt.Errorf("processor.PlayFilter.Validate: bad host pattern %q: %w", f.HostPattern, err)
+	}
+
+	return nil
+}
+
+func (f PlayFilter) Match(pl *Play) bool {
+	if f.Number != 0 && f.Number != pl.Number {
+		return false
+	}
+
+	if f.HostPattern != "" {
+		if ok, _ := path.Match(f.HostPattern, pl.HostPattern); !ok {
+			return false
+		}
+	}
+
+	if f.Title != "" && !strings.Contains(strings.ToLower(pl.Title), strings.ToLower(f.Title)) {
+		return false
+	}
+
+	return true
+}
+
+// FilterPlays returns a new Result holding only plays matched by `f` along with their
+// sections. Rows preceding the first play are kept. Stats and PlayStats are recalculated.
+//
+// Plays of run output are matched by number and title only: run output carries no host
+// pattern, so the host pattern of `f` is ignored for them. `PLAY RECAP` is always kept.
+func (r *Result) FilterPlays(f PlayFilter) *Result {
+	rows := make([]*Row, 0, len(r.Rows))
+	plays := make([]*Play, 0, len(r.Plays))
+	stats := &Stats{Widther: r.Stats.Widther, InputFormat: r.Stats.InputFormat}
+
+	isKeep := true
+	runFilter := f
+	runFilter.HostPattern = ""
+
+	for _, row := range r.Rows {
+		switch t := row.Data.(type) {
+		case *Play:
+			isKeep = f.Match(t)
+
+			if isKeep {
+				plays = append(plays, t)
+			}
+
+		case *RunPlay:
+			isKeep = runFilter.Match(&Play{Name: t.String(), Number: t.Number, Title: t.Name})
+
+		case *Recap:
+			isKeep = true
+		}
+
+		if !isKeep {
+			continue
+		}
+
+		rows = append(rows, row)
+		stats.updateWithRow(row)
+	}
+
+	stats.updatePercentiles(rows)
+
+	return &Result{rows, plays, stats, collectPlayStats(rows, stats), r.Indents, r.Warnings}
+}
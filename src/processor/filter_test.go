@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+)
+
+func Test_PlayFilter(t *testing.T) {
+	play := &Play{Name: "play #2 (web:&staging): Deploy Web", Number: 2, HostPattern: "web:&staging", Title: "Deploy Web"}
+
+	t.Run("Match()", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			filter PlayFilter
+			want   bool
+		}{
+			{"empty", PlayFilter{}, true},
+			{"number", PlayFilter{Number: 2}, true},
+			{"other number", PlayFilter{Number: 1}, false},
+			{"host pattern", PlayFilter{HostPattern: "web:*"}, true},
+			{"other host pattern", PlayFilter{HostPattern: "db*"}, false},
+			{"title", PlayFilter{Title: "deploy"}, true},
+			{"other title", PlayFilter{Title: "database"}, false},
+			{"all fields", PlayFilter{Number: 2, HostPattern: "*staging", Title: "WEB"}, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got := tt.filter.Match(play)
+
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("IsEmpty()", func(t *testing.T) {
+		want := []bool{true, false}
+		got := []bool{PlayFilter{}.IsEmpty(), PlayFilter{Title: "web"}.IsEmpty()}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Validate(): returns error upon bad host pattern", func(t *testing.T) {
+		if err := (PlayFilter{HostPattern: "web*"}).Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if err := (PlayFilter{HostPattern: "web["}).Validate(); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func TestResult_FilterPlays(t *testing.T) {
+	process := func(lines ...string) *Result {
+		var ll cmn.LineBuilder
+
+		for _, line := range lines {
+			ll.WriteLine(line)
+		}
+
+		result, err := ProcessLines(bufio.NewScanner(strings.NewReader(ll.String())), cmn.RunesWidther{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	header := []string{
+		"playbook: playbooks/vsp/playbook_vps.yml",
+		"",
+	}
+
+	play1 := []string{
+		"  play #1 (vps): A long play title	TAGS: [long-play-tag]",
+		"    tasks:",
+		"      A long task name	TAGS: [long-task-tag]",
+		"",
+	}
+
+	play2 := []string{
+		"  play #2 (db): Demo 2	TAGS: []",
+		"    pattern: ['db']",
+		"    hosts (1):",
+		"      db1",
+		"    tasks:",
+		"      Task 2.1	TAGS: [demo]",
+	}
+
+	all := append(append(append([]string{}, header...), play1...), play2...)
+	only2 := append(append([]string{}, header...), play2...)
+
+	full := process(all...)
+	got := full.FilterPlays(PlayFilter{HostPattern: "db"})
+
+	t.Run("Keeps leading rows and matched plays", func(t *testing.T) {
+		want := &Result{
+			Rows:      append(append([]*Row{}, full.Rows[:2]...), full.Rows[len(full.Rows)-4:]...),
+			Plays:     full.Plays[1:],
+			Stats:     got.Stats,
+			PlayStats: got.PlayStats,
+			Indents:   full.Indents,
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Recalculates stats", func(t *testing.T) {
+		want := process(only2...).Stats
+		want.MaxTaskLine = len(all) // Tasks keep lines of the full input
+
+		if diff := cmp.Diff(want, got.Stats); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		// Stats of a play don't depend on other plays
+		if diff := cmp.Diff(full.PlayStats[1:], got.PlayStats); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Filters run output by play number and keeps recap", func(t *testing.T) {
+		input := `PLAY [Web] ****
+TASK [Ping] ****
+ok: [web01]
+PLAY [Databases] ****
+TASK [Ping] ****
+ok: [db01]
+PLAY RECAP ****
+web01  : ok=1  changed=0  unreachable=0  failed=0
+db01   : ok=1  changed=0  unreachable=0  failed=0
+`
+		run, err := NewProcessor().SetFormat(FormatRun).Process(bufio.NewScanner(strings.NewReader(input)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := run.FilterPlays(PlayFilter{Number: 2})
+		want := []*Row{run.Rows[2], run.Rows[3], run.Rows[4]}
+
+		if diff := cmp.Diff(want, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		// Run output carries no host pattern to match
+		got = run.FilterPlays(PlayFilter{HostPattern: "web*", Title: "data"})
+
+		if diff := cmp.Diff(want, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		got = run.FilterPlays(PlayFilter{HostPattern: "web*"})
+
+		if diff := cmp.Diff(run.Rows, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+}
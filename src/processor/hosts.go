@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import "fmt"
+
+// Hosts is a `pattern:` / `hosts (N):` section of `ansible-playbook --list-hosts` output
+type Hosts struct {
+	PlayNumber int
+	Pattern    string // Raw pattern as printed by ansible, e.g. "['webservers']"
+	Count      int    // Host count as reported by ansible
+	Hosts      []string
+}
+
+func (hs *Hosts) String() string {
+	return fmt.Sprintf("[Play #%d - Hosts]", hs.PlayNumber)
+}
+
+func (hs *Hosts) Add(host string) {
+	hs.Hosts = append(hs.Hosts, host)
+}
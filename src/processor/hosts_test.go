@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Hosts(t *testing.T) {
+	hosts := &Hosts{PlayNumber: 42, Pattern: "['all']", Count: 2, Hosts: []string{"host1"}}
+
+	t.Run("Implements 'Stringer' interface", func(t *testing.T) {
+		got := hosts.String()
+		want := "[Play #42 - Hosts]"
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Add(): adds host", func(t *testing.T) {
+		want := []string{"host1", "host2"}
+
+		hosts.Add("host2")
+
+		got := hosts.Hosts
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Largest line of a JSON document, e.g. a long `msg` of an unindented document
+const maxJsonLineSize = 16 * 1024 * 1024
+
+// jsonCallback is a document of the `json` stdout callback. Objects keyed by host keep
+// the document order, see jsonObject.
+type jsonCallback struct {
+	Plays []struct {
+		Play struct {
+			Name string `json:"name"`
+		} `json:"play"`
+		Tasks []struct {
+			Task struct {
+				Name string `json:"name"`
+			} `json:"task"`
+			Hosts jsonObject `json:"hosts"`
+		} `json:"tasks"`
+	} `json:"plays"`
+	Stats map[string]struct {
+		Ok          int `json:"ok"`
+		Changed     int `json:"changed"`
+		Unreachable int `json:"unreachable"`
+		Failures    int `json:"failures"`
+		Skipped     int `json:"skipped"`
+		Rescued     int `json:"rescued"`
+		Ignored     int `json:"ignored"`
+	} `json:"stats"`
+}
+
+// jsonObject is a JSON object with keys in document order
+type jsonObject struct {
+	Keys   []string
+	Values []json.RawMessage
+}
+
+func (o *jsonObject) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return errors.New("processor.jsonObject.UnmarshalJSON: object expected")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		var value json.RawMessage
+
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		o.Keys = append(o.Keys, token.(string))
+		o.Values = append(o.Values, value)
+	}
+
+	return nil
+}
+
+// jsonHostResult is a task result of a host. Loop tasks carry a result per item.
+type jsonHostResult struct {
+	Changed     bool             `json:"changed"`
+	Failed      bool             `json:"failed"`
+	Skipped     bool             `json:"skipped"`
+	Unreachable bool             `json:"unreachable"`
+	Msg         any              `json:"msg"`
+	Item        any              `json:"item"`
+	ItemLabel   any              `json:"_ansible_item_label"`
+	Results     []jsonHostResult `json:"results"`
+}
+
+func (r *jsonHostResult) status(isItem bool) string {
+	switch {
+	case r.Unreachable:
+		return StatusUnreachable
+	case r.Failed && isItem:
+		return StatusFailed
+	case r.Failed:
+		return StatusFatal
+	case r.Skipped:
+		return StatusSkipping
+	case r.Changed:
+		return StatusChanged
+	}
+
+	return StatusOk
+}
+
+// jsonText renders a decoded JSON value as plain text: strings as is, anything else as JSON
+func jsonText(value any) string {
+	switch t := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	}
+
+	data, _ := json.Marshal(value)
+
+	return string(data)
+}
+
+// processJsonHost converts a host result into a HostResult per loop item or a single one
+func processJsonHost(host string, data json.RawMessage) ([]*HostResult, error) {
+	var r jsonHostResult
+
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("processor.processJsonHost: %w", err)
+	}
+
+	if len(r.Results) == 0 {
+		return []*HostResult{{Host: host, Status: r.status(false), Message: jsonText(r.Msg)}}, nil
+	}
+
+	results := make([]*HostResult, 0, len(r.Results))
+
+	for _, ir := range r.Results {
+		item := jsonText(ir.ItemLabel)
+		if item == "" {
+			item = jsonText(ir.Item)
+		}
+
+		results = append(results, &HostResult{Host: host, Status: ir.status(true), Item: item, Message: jsonText(ir.Msg)})
+	}
+
+	return results, nil
+}
+
+// jsonHostError is an error of a host result, see processJsonHost. It's reported by play, task
+// and host names as offsets of the underlying error are relative to the host result, not to
+// the document.
+type jsonHostError struct {
+	Play string
+	Task string
+	Host string
+	Err  error
+}
+
+func (e *jsonHostError) Error() string {
+	return fmt.Sprintf("play %q, task %q, host %q: %v", e.Play, e.Task, e.Host, e.Err)
+}
+
+func (e *jsonHostError) Unwrap() error {
+	return e.Err
+}
+
+// jsonErrorLine returns 1-based line and column of a byte offset
+func jsonErrorLine(data []byte, offset int64) (line int, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = len(before) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
+
+// jsonRows converts a parsed document into rows of the run output model. Decoded text
+// values are cleaned like input lines are.
+func (pr *Processor) jsonRows(doc *jsonCallback, stats *Stats) ([]*Row, error) {
+	rows := make([]*Row, 0, 2)
+
+	for playIdx, jp := range doc.Plays {
+		play := &RunPlay{playIdx + 1, pr.clean(jp.Play.Name)}
+
+		rows = append(rows, &Row{0, Passthru("")}, &Row{0, play})
+		stats.updateWithRunPlay(play)
+
+		for _, jt := range jp.Tasks {
+			taskName := pr.clean(jt.Task.Name)
+
+			block, name, found := strings.Cut(taskName, " : ")
+			if !found {
+				block, name = "", taskName
+			}
+
+			task := &RunTask{PlayNumber: play.Number, Kind: KindTask, Block: block, Name: name}
+
+			for i, host := range jt.Hosts.Keys {
+				results, err := processJsonHost(host, jt.Hosts.Values[i])
+				if err != nil {
+					return nil, &jsonHostError{play.Name, taskName, pr.clean(host), err}
+				}
+
+				for _, hr := range results {
+					hr.Host = pr.clean(hr.Host)
+					hr.Item = pr.clean(hr.Item)
+					hr.Message = pr.clean(hr.Message)
+
+					task.Add(hr)
+					stats.updateHost(hr.Host)
+				}
+			}
+
+			rows = append(rows, &Row{0, Passthru("")}, &Row{0, task})
+			stats.updateWithRunTask(task)
+		}
+	}
+
+	if len(doc.Stats) > 0 {
+		recap := &Recap{}
+		hosts := make([]string, 0, len(doc.Stats))
+
+		for host := range doc.Stats {
+			hosts = append(hosts, host)
+		}
+
+		// Sorted as in `PLAY RECAP` of the default callback
+		sort.Strings(hosts)
+
+		for _, host := range hosts {
+			s := doc.Stats[host]
+			rh := &RecapHost{pr.clean(host), s.Ok, s.Changed, s.Unreachable, s.Failures, s.Skipped, s.Rescued, s.Ignored}
+
+			recap.Add(rh)
+			stats.updateWithRecapHost(rh)
+		}
+
+		rows = append(rows, &Row{0, Passthru("")}, &Row{0, recap})
+	}
+
+	return rows, nil
+}
+
+// processJson parses a document of the `json` stdout callback into the run output model.
+// Lines preceding the document, e.g. warnings, are kept as passthru. Lenient parse of
+// a malformed document keeps the whole document as passthru.
+//
+// The document is decoded as is: control characters are legal within JSON strings, so
+// cleaning applies to decoded values only, see jsonRows.
+func (pr *Processor) processJson(scanner *bufio.Scanner) (*Result, error) {
+	var (
+		doc   jsonCallback
+		lines []string
+		errs  ParseErrors
+	)
+
+	rows := make([]*Row, 0, 2)
+	stats := &Stats{Widther: pr.widther}
+
+	scanner.Buffer(nil, maxJsonLineSize)
+
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := pr.clean(raw)
+
+		if lines == nil && !strings.HasPrefix(strings.TrimSpace(line), "{") {
+			rows = append(rows, &Row{calcIndent(line), pr.passthru(raw, line)})
+			continue
+		}
+
+		lines = append(lines, raw)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	offset := len(rows)
+	data := []byte(strings.Join(lines, "\n"))
+
+	err := json.Unmarshal(data, &doc)
+
+	if err == nil {
+		var docRows []*Row
+
+		if docRows, err = pr.jsonRows(&doc, stats); err == nil {
+			rows = append(rows, docRows...)
+		}
+	}
+
+	if err != nil {
+		var (
+			hostErr            *jsonHostError
+			syntaxErr          *json.SyntaxError
+			typeErr            *json.UnmarshalTypeError
+			lineNumber, column int
+		)
+
+		// Errors of a host result have no position within the document
+		if errors.As(err, &hostErr) {
+			lineNumber, column = 0, 0
+		} else if errors.As(err, &syntaxErr) {
+			lineNumber, column = jsonErrorLine(data, syntaxErr.Offset)
+		} else if errors.As(err, &typeErr) {
+			lineNumber, column = jsonErrorLine(data, typeErr.Offset)
+		}
+
+		text := ""
+		if lineNumber > 0 && lineNumber <= len(lines) {
+			text = pr.clean(lines[lineNumber-1])
+			lineNumber += offset
+		}
+
+		errs = append(errs, &ParseError{lineNumber, column, text, fmt.Errorf("processor.processJson: %w", err)})
+
+		stats = &Stats{Widther: pr.widther}
+		for _, raw := range lines {
+			line := pr.clean(raw)
+			rows = append(rows, &Row{calcIndent(line), pr.passthru(raw, line)})
+		}
+	}
+
+	if len(errs) > 0 && !pr.isLenient {
+		return nil, errs
+	}
+
+	return &Result{rows, []*Play{}, stats, nil, Indents{-1, -1, -1}, errs}, nil
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_processJsonHost(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []*HostResult
+	}{
+		{"ok", `{"changed": false}`, []*HostResult{{Host: "h", Status: StatusOk}}},
+		{"changed", `{"changed": true}`, []*HostResult{{Host: "h", Status: StatusChanged}}},
+		{"skipped", `{"skipped": true}`, []*HostResult{{Host: "h", Status: StatusSkipping}}},
+		{"fatal", `{"failed": true, "msg": "boom"}`, []*HostResult{{Host: "h", Status: StatusFatal, Message: "boom"}}},
+		{"unreachable", `{"unreachable": true, "msg": "ssh"}`, []*HostResult{{Host: "h", Status: StatusUnreachable, Message: "ssh"}}},
+		{"non-string msg", `{"failed": true, "msg": {"rc": 1}}`, []*HostResult{{Host: "h", Status: StatusFatal, Message: `{"rc":1}`}}},
+		{
+			name: "loop",
+			data: `{"results": [{"item": "curl"}, {"item": {"name": "git"}, "_ansible_item_label": "git", "failed": true}]}`,
+			want: []*HostResult{
+				{Host: "h", Status: StatusOk, Item: "curl"},
+				{Host: "h", Status: StatusFailed, Item: "git"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := processJsonHost("h", []byte(tt.data))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProcessor_processJson(t *testing.T) {
+	input := `[WARNING]: something
+{
+  "plays": [
+    {
+      "play": {"name": "Web servers"},
+      "tasks": [
+        {
+          "task": {"name": "nginx : Install nginx"},
+          "hosts": {"web02": {"changed": false}, "web01": {"changed": true}}
+        }
+      ]
+    }
+  ],
+  "stats": {
+    "web02": {"ok": 1, "changed": 0, "unreachable": 0, "failures": 0, "skipped": 0, "rescued": 0, "ignored": 0},
+    "web01": {"ok": 1, "changed": 1, "unreachable": 0, "failures": 0, "skipped": 0, "rescued": 0, "ignored": 0}
+  }
+}
+`
+
+	fnProcess := func(pr *Processor, input string) (*Result, error) {
+		return pr.SetFormat(FormatJson).Process(bufio.NewScanner(strings.NewReader(input)))
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		got, err := fnProcess(NewProcessor(), input)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []*Row{
+			{0, Passthru("[WARNING]: something")},
+			{0, Passthru("")},
+			{0, &RunPlay{1, "Web servers"}},
+			{0, Passthru("")},
+			{0, &RunTask{PlayNumber: 1, Kind: KindTask, Block: "nginx", Name: "Install nginx", Results: []*HostResult{
+				{Host: "web02", Status: StatusOk},
+				{Host: "web01", Status: StatusChanged},
+			}}},
+			{0, Passthru("")},
+			{0, &Recap{Hosts: []*RecapHost{
+				{Host: "web01", Ok: 1, Changed: 1},
+				{Host: "web02", Ok: 1},
+			}}},
+		}
+
+		if diff := cmp.Diff(want, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if diff := cmp.Diff(2, got.Stats.HostsCount); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	malformed := "[WARNING]: something\n{\n  \"plays\": [\n    {\"play\": }\n  ]\n}\n"
+
+	t.Run("strict: returns parse error with line number", func(t *testing.T) {
+		_, err := fnProcess(NewProcessor(), malformed)
+
+		var errs ParseErrors
+
+		if !errors.As(err, &errs) || len(errs) != 1 {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := &ParseError{Line: 4, Column: 15, Text: `    {"play": }`}
+		got := &ParseError{Line: errs[0].Line, Column: errs[0].Column, Text: errs[0].Text}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("strict: reports a malformed host result by names", func(t *testing.T) {
+		input := "[WARNING]: something\n" +
+			`{"plays": [{"play": {"name": "Web"}, "tasks": [{"task": {"name": "Ping"}, "hosts": {"web01": {"changed": "yes"}}}]}]}`
+
+		_, err := fnProcess(NewProcessor(), input)
+
+		var errs ParseErrors
+
+		if !errors.As(err, &errs) || len(errs) != 1 {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := `processor.processJson: play "Web", task "Ping", host "web01": processor.processJsonHost: ` +
+			`json: cannot unmarshal string into Go struct field jsonHostResult.changed of type bool`
+
+		if diff := cmp.Diff(want, errs.Error()); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("lenient: keeps malformed document as passthru", func(t *testing.T) {
+		got, err := fnProcess(NewProcessor().SetIsLenient(true), malformed)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(6, len(got.Rows)); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if len(got.Warnings) != 1 {
+			t.Errorf("expected 1 warning, got %d", len(got.Warnings))
+		}
+	})
+
+	t.Run("control characters: sanitized once decoded", func(t *testing.T) {
+		input := "{\"plays\": [{\"play\": {\"name\": \"Web\x7f\"}, \"tasks\": []}]}"
+
+		got, err := fnProcess(NewProcessor(), input)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(&RunPlay{1, `Web\x7f`}, got.Rows[1].Data); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		if _, err := fnProcess(NewProcessor(), ""); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is an input line that can't be parsed
+type ParseError struct {
+	Line   int    // 1-based line number; 0 if the error has no position, e.g. one of a nested JSON value
+	Column int    // 1-based column where the offending text starts
+	Text   string // Offending line as is
+	Err    error  // Underlying error, e.g. "processor.processTask: unexpected task format"
+}
+
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("line %d, column %d: %v: %q", e.Line, e.Column, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors are all errors found by a strict parse, in the order of appearance
+type ParseErrors []*ParseError
+
+func (es ParseErrors) Error() string {
+	lines := make([]string, 0, len(es))
+
+	for _, e := range es {
+		lines = append(lines, e.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
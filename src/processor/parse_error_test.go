@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ParseError(t *testing.T) {
+	errTask := errors.New("processor.processTask: unexpected task format")
+	e := &ParseError{Line: 6, Column: 7, Text: "      Task 1.1", Err: errTask}
+
+	t.Run("Implements 'error' interface", func(t *testing.T) {
+		got := e.Error()
+		want := `line 6, column 7: processor.processTask: unexpected task format: "      Task 1.1"`
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("No position: underlying error only", func(t *testing.T) {
+		got := (&ParseError{Err: errTask}).Error()
+
+		if diff := cmp.Diff(errTask.Error(), got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Unwrap(): returns underlying error", func(t *testing.T) {
+		if !errors.Is(e, errTask) {
+			t.Errorf("expected underlying error")
+		}
+	})
+
+	t.Run("ParseErrors: one error per line", func(t *testing.T) {
+		es := ParseErrors{e, &ParseError{Line: 9, Column: 3, Text: "  play", Err: errTask}}
+
+		got := es.Error()
+		want := "line 6, column 7: processor.processTask: unexpected task format: \"      Task 1.1\"\n" +
+			"line 9, column 3: processor.processTask: unexpected task format: \"  play\""
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+}
@@ -7,8 +7,15 @@ package processor
 import "fmt"
 
 type Play struct {
-	Name string
-	Tags string
+	Name        string    // Whole play header, e.g. "play #1 (vps): Test"
+	Number      int       // Play number; 0 if header is not recognized
+	HostPattern string    // Host pattern in parentheses, e.g. "vps"
+	Title       string    // Human name of the play, e.g. "Test"
+	Tags        string    // Raw tags as printed by ansible, e.g. "[tag1, tag2]"
+	TagList     []string  // Parsed tags, see ParseTags
+	Hosts       *Hosts    // `--list-hosts` section; nil if absent
+	Tasks       *Tasks    // `--list-tasks` section; nil if absent
+	TaskTags    *TaskTags // `--list-tags` section; nil if absent
 }
 
 func (pl *Play) Description() string {
@@ -8,19 +8,153 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/keewek/ansible-pretty-print/src/cmn"
 )
 
 type Row struct {
-	Indent int          // TODO: ATM `Indent` is a deadweight.
-	Data   fmt.Stringer //       Drop it or implement indent detection
+	Indent int // Indentation of the source line; of the first item line for sections
+	Data   fmt.Stringer
+}
+
+// Indents holds indentation detected in the input, -1 if not detected
+type Indents struct {
+	Play    int // `play #1 ...` lines
+	Section int // `pattern:`, `hosts (N):` and `tasks:` lines
+	Item    int // host, task and `TASK TAGS:` lines
 }
 
 type Result struct {
-	Rows  []*Row
-	Stats *Stats
+	Rows      []*Row
+	Plays     []*Play // Plays with their sections attached, in the order of appearance
+	Stats     *Stats
+	PlayStats []*Stats // Stats of each play row, *Play or *RunPlay, in the order of appearance
+	Indents   Indents
+	Warnings  ParseErrors // Lines demoted to passthru by a lenient parse
+}
+
+// defaultItemOffset is indentation of items relative to their section in ansible-playbook
+// output, assumed until detected
+const defaultItemOffset = 2
+
+// Processor parses ansible-playbook listing output. By default parse is strict: every
+// malformed line is reported and no result is returned. Lenient parse demotes malformed
+// lines to passthru and reports them as Result.Warnings.
+type Processor struct {
+	widther      cmn.Widther
+	format       string
+	sanitize     string
+	tabStop      int
+	isLenient    bool
+	isKeepColors bool
+}
+
+// Input formats
+const (
+	FormatList = "list" // `--list-tasks`, `--list-tags` and `--list-hosts` output
+	FormatRun  = "run"  // Run output of the default stdout callback
+	FormatJson = "json" // Run output of the `json` stdout callback
+	FormatAuto = "auto" // Detected by the first lines, see DetectFormat
+)
+
+func NewProcessor() *Processor {
+	return &Processor{
+		widther:  cmn.RunesWidther{},
+		format:   FormatList,
+		sanitize: cmn.SanitizeEscape,
+		tabStop:  cmn.DefaultTabStop,
+	}
+}
+
+func (pr *Processor) SetWidther(value cmn.Widther) *Processor {
+	pr.widther = value
+
+	return pr
+}
+
+func (pr *Processor) SetFormat(value string) *Processor {
+	pr.format = value
+
+	return pr
+}
+
+func (pr *Processor) SetIsLenient(value bool) *Processor {
+	pr.isLenient = value
+
+	return pr
+}
+
+// SetIsKeepColors keeps escape sequences, e.g. colors, in passthru rows. Escape sequences
+// are stripped before parsing regardless.
+func (pr *Processor) SetIsKeepColors(value bool) *Processor {
+	pr.isKeepColors = value
+
+	return pr
+}
+
+// SetSanitize sets the style control characters of the input are rendered in, see cmn.Sanitize
+func (pr *Processor) SetSanitize(value string) *Processor {
+	pr.sanitize = value
+
+	return pr
+}
+
+// SetTabStop sets the tab stop tabs of the input are expanded to, see cmn.ExpandTabs
+func (pr *Processor) SetTabStop(value int) *Processor {
+	pr.tabStop = value
+
+	return pr
+}
+
+// clean strips escape sequences, sanitizes control characters and expands tabs of the input
+func (pr *Processor) clean(raw string) string {
+	return pr.expandTabs(cmn.Sanitize(cmn.StripAnsi(raw), pr.sanitize))
+}
+
+func (pr *Processor) expandTabs(s string) string {
+	return cmn.ExpandTabs(s, pr.tabStop, pr.widther.Width)
+}
+
+// passthru returns a passthru row value of the `raw` input line cleaned into `line`
+func (pr *Processor) passthru(raw string, line string) Passthru {
+	if pr.isKeepColors {
+		return Passthru(pr.expandTabs(cmn.SanitizeKeepColors(raw, pr.sanitize)))
+	}
+
+	return Passthru(line)
+}
+
+// parsePlayName splits `play #1 (vps): Test` into play number, host pattern and title.
+func parsePlayName(name string) (number int, hostPattern string, title string, ok bool) {
+	if !strings.HasPrefix(name, "play #") {
+		return 0, "", "", false
+	}
+
+	rest := strings.TrimPrefix(name, "play #")
+
+	digits, rest, found := strings.Cut(rest, " (")
+	if !found {
+		return 0, "", "", false
+	}
+
+	number, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	// Host pattern may contain parentheses, e.g. `web:&(staging)`
+	idx := strings.LastIndex(rest, "):")
+	if i := strings.Index(rest, "): "); i >= 0 {
+		idx = i
+	}
+
+	if idx < 0 {
+		return 0, "", "", false
+	}
+
+	return number, rest[:idx], strings.TrimSpace(rest[idx+2:]), true
 }
 
 func processPlay(line string) (*Play, error) {
@@ -30,10 +164,19 @@ func processPlay(line string) (*Play, error) {
 		name := strings.TrimSpace(pair[0])
 		tags := strings.TrimSpace(pair[1])
 
-		return &Play{
-			Name: name,
-			Tags: tags,
-		}, nil
+		play := &Play{
+			Name:    name,
+			Tags:    tags,
+			TagList: ParseTags(tags),
+		}
+
+		if number, hostPattern, title, ok := parsePlayName(name); ok {
+			play.Number = number
+			play.HostPattern = hostPattern
+			play.Title = title
+		}
+
+		return play, nil
 	}
 
 	return nil, errors.New("processor.processPlay: unexpected play format")
@@ -55,9 +198,10 @@ func processTask(line string) (*Task, error) {
 		}
 
 		return &Task{
-			Block: block,
-			Name:  name,
-			Tags:  tags,
+			Block:   block,
+			Name:    name,
+			Tags:    tags,
+			TagList: ParseTags(tags),
 		}, nil
 	}
 
@@ -65,79 +209,354 @@ func processTask(line string) (*Task, error) {
 
 }
 
-// func calcIndent(line string) int {
-// 	size := len(line)
-// 	indent := 0
+func processTaskTags(line string, playNumber int) (*TaskTags, error) {
+	pair := strings.SplitN(strings.TrimSpace(line), "TASK TAGS:", 2)
+
+	if len(pair) == 2 && strings.TrimSpace(pair[0]) == "" {
+		tags := strings.TrimSpace(pair[1])
+
+		return &TaskTags{
+			PlayNumber: playNumber,
+			Tags:       tags,
+			TagList:    ParseTags(tags),
+		}, nil
+	}
+
+	return nil, errors.New("processor.processTaskTags: unexpected task tags format")
+}
+
+func processHostsPattern(line string, playNumber int) (*Hosts, error) {
+	pair := strings.SplitN(strings.TrimSpace(line), "pattern:", 2)
 
-// 	for i := 0; i < size; i++ {
+	if len(pair) == 2 && strings.TrimSpace(pair[0]) == "" {
+		return &Hosts{
+			PlayNumber: playNumber,
+			Pattern:    strings.TrimSpace(pair[1]),
+		}, nil
+	}
 
-// 		if line[i] != ' ' {
-// 			break
-// 		}
+	return nil, errors.New("processor.processHostsPattern: unexpected hosts pattern format")
+}
 
-// 		indent++
-// 	}
+func processHostsCount(line string) (int, error) {
+	var count int
 
-// 	return indent
-// }
+	value := strings.TrimSpace(line)
 
+	if _, err := fmt.Sscanf(value, "hosts (%d):", &count); err != nil || !strings.HasSuffix(value, "):") {
+		return 0, errors.New("processor.processHostsCount: unexpected hosts count format")
+	}
+
+	return count, nil
+}
+
+func calcIndent(line string) int {
+	size := len(line)
+	indent := 0
+
+	for i := 0; i < size; i++ {
+
+		if line[i] != ' ' {
+			break
+		}
+
+		indent++
+	}
+
+	return indent
+}
+
+// itemOffset returns indentation of items relative to their section, the detected one if any
+func itemOffset(detected Indents) int {
+	if detected.Item < 0 || detected.Section < 0 || detected.Item <= detected.Section {
+		return defaultItemOffset
+	}
+
+	return detected.Item - detected.Section
+}
+
+func detectIndent(detected *int, indent int) {
+	if *detected < 0 {
+		*detected = indent
+	}
+}
+
+// ProcessLines parses ansible-playbook listing output in strict mode, see Processor
 func ProcessLines(scanner *bufio.Scanner, widther cmn.Widther) (*Result, error) {
-	var tasks *Tasks
+	return NewProcessor().SetWidther(widther).Process(scanner)
+}
+
+// Process parses ansible-playbook output of the configured format. Strict parse returns
+// ParseErrors holding every malformed line. FormatAuto expects a scanner that hasn't
+// been scanned yet.
+func (pr *Processor) Process(scanner *bufio.Scanner) (*Result, error) {
+	var (
+		result *Result
+		err    error
+	)
+
+	format := pr.format
+
+	switch format {
+	case "":
+		format = FormatList
+	case FormatAuto:
+		format, scanner = peekFormat(scanner)
+	}
+
+	switch format {
+	case FormatList:
+		result, err = pr.processList(scanner)
+	case FormatRun:
+		result, err = pr.processRun(scanner)
+	case FormatJson:
+		result, err = pr.processJson(scanner)
+	default:
+		return nil, fmt.Errorf("processor.Process: unknown input format %q", pr.format)
+	}
+
+	if result != nil {
+		result.Stats.InputFormat = format
+		result.Stats.updatePercentiles(result.Rows)
+		result.PlayStats = collectPlayStats(result.Rows, result.Stats)
+	}
+
+	return result, err
+}
+
+// processList parses listing output. Structure is derived from relative indentation rather
+// than fixed offsets: sections are indented deeper than their play, items deeper than their
+// section. A section ends with the first line that isn't.
+//
+// Sections of a malformed play belong to no play: they're parsed, so malformed lines are
+// still reported, but kept as passthru.
+func (pr *Processor) processList(scanner *bufio.Scanner) (*Result, error) {
+	var (
+		play     *Play
+		tasks    *Tasks
+		hosts    *Hosts
+		tasksRow *Row
+		errs     ParseErrors
+	)
 
 	rows := make([]*Row, 0, 2)
-	stats := &Stats{Widther: widther}
+	plays := make([]*Play, 0, 1)
+	stats := &Stats{Widther: pr.widther}
+	indents := Indents{-1, -1, -1}
 
+	lineNumber := 0
+	raw := ""
 	playsCount := 0
+	playNumber := 0 // Of the current play as in its header, its ordinal if the header has none
+	playIndent := 0
+	sectionIndent := 0
 	isProcessTasks := false
+	isProcessHosts := false
+	isOrphan := false
+	isPlaySeen := false
+
+	// Malformed line is kept as passthru so parsing goes on
+	fnFail := func(line string, indent int, err error) {
+		errs = append(errs, &ParseError{lineNumber, indent + 1, line, err})
+		rows = append(rows, &Row{indent, pr.passthru(raw, line)})
+	}
+
+	// Line of a malformed play is kept as passthru once parsed
+	fnOrphan := func(line string, indent int) bool {
+		if isOrphan {
+			rows = append(rows, &Row{indent, pr.passthru(raw, line)})
+		}
+
+		return isOrphan
+	}
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		// indent := calcIndent(line)
+		raw = scanner.Text()
+		line := pr.clean(raw)
+		text := strings.TrimSpace(line)
+		indent := calcIndent(line)
 
-		if strings.HasPrefix(line, "  play") {
-			playsCount++
+		lineNumber++
+
+		isItem := text != "" && indent > sectionIndent
+		isSection := indent > playIndent || (play == nil && indent > 0)
+		isPlay := strings.HasPrefix(text, "play #") && (!isPlaySeen || indent <= playIndent)
+
+		if isProcessHosts && isItem {
+			if fnOrphan(line, indent) {
+				continue
+			}
+
+			hosts.Add(text)
+
+			detectIndent(&indents.Item, indent)
+			stats.updateHost(text)
+			continue
+		}
 
-			play, err := processPlay(line)
+		if isProcessTasks && isItem && !strings.HasPrefix(text, "TASK TAGS:") {
+			task, err := processTask(line)
 			if err != nil {
-				return nil, err
+				fnFail(line, indent, err)
+				continue
+			}
+
+			if fnOrphan(line, indent) {
+				continue
+			}
+
+			if len(tasks.Tasks) == 0 {
+				tasksRow.Indent = indent
 			}
 
-			rows = append(rows, &Row{Indent: 2, Data: play})
+			task.Line = lineNumber
+			tasks.Add(task)
+
+			detectIndent(&indents.Item, indent)
+			stats.updateWithTask(task)
+			stats.updatePlayTasksCount(len(tasks.Tasks))
+			continue
+		}
+
+		isProcessHosts = isProcessHosts && isItem
+		isProcessTasks = isProcessTasks && isItem
+
+		if isPlay {
+			isPlaySeen = true
+			playIndent = indent
+			sectionIndent = indent
 
+			p, err := processPlay(line)
+			if err != nil {
+				play = nil
+				isOrphan = true
+				fnFail(line, indent, err)
+				continue
+			}
+
+			playsCount++
+			isOrphan = false
+
+			playNumber = p.Number
+			if playNumber == 0 {
+				playNumber = playsCount
+			}
+
+			play = p
+			plays = append(plays, play)
+			rows = append(rows, &Row{Indent: indent, Data: play})
+
+			detectIndent(&indents.Play, indent)
 			stats.updateWithPlay(play)
 			continue
 
-		} else if strings.HasPrefix(line, "    tasks") {
-			isProcessTasks = true
-			tasks = &Tasks{PlayNumber: playsCount}
+		} else if isSection && strings.HasPrefix(text, "pattern:") {
+			h, err := processHostsPattern(line, playNumber)
+			if err != nil {
+				fnFail(line, indent, err)
+				continue
+			}
+
+			if fnOrphan(line, indent) {
+				continue
+			}
 
-			rows = append(rows, &Row{Data: Passthru(line)})
-			rows = append(rows, &Row{6, tasks})
+			hosts = h
+			rows = append(rows, &Row{indent, hosts})
+
+			if play != nil {
+				play.Hosts = hosts
+			}
+
+			detectIndent(&indents.Section, indent)
 			continue
 
-		} else if isProcessTasks {
+		} else if isSection && strings.HasPrefix(text, "hosts (") {
+			count, err := processHostsCount(line)
+			if err != nil {
+				fnFail(line, indent, err)
+				continue
+			}
+
+			isProcessHosts = true
+			sectionIndent = indent
+
+			if fnOrphan(line, indent) {
+				continue
+			}
+
+			if hosts == nil || hosts.PlayNumber != playNumber {
+				hosts = &Hosts{PlayNumber: playNumber}
+				rows = append(rows, &Row{indent, hosts})
 
-			if strings.HasPrefix(line, "      ") {
-				task, err := processTask(line)
-				if err != nil {
-					return nil, err
+				if play != nil {
+					play.Hosts = hosts
 				}
+			}
+
+			hosts.Count = count
 
-				tasks.Add(task)
+			detectIndent(&indents.Section, indent)
+			stats.updateHostsCount(count)
+			continue
+
+		} else if isSection && strings.HasPrefix(text, "tasks") {
+			isProcessTasks = true
+			sectionIndent = indent
 
-				stats.updateWithTask(task)
+			if fnOrphan(line, indent) {
 				continue
+			}
+
+			tasks = &Tasks{PlayNumber: playNumber}
+			tasksRow = &Row{indent + itemOffset(indents), tasks}
+
+			rows = append(rows, &Row{indent, pr.passthru(raw, line)})
+			rows = append(rows, tasksRow)
 
-			} else {
-				isProcessTasks = false
+			if play != nil {
+				play.Tasks = tasks
 			}
+
+			detectIndent(&indents.Section, indent)
+			continue
+
+		} else if isSection && strings.HasPrefix(text, "TASK TAGS:") {
+			taskTags, err := processTaskTags(line, playNumber)
+			if err != nil {
+				fnFail(line, indent, err)
+				continue
+			}
+
+			if fnOrphan(line, indent) {
+				continue
+			}
+
+			rows = append(rows, &Row{indent, taskTags})
+
+			if play != nil {
+				play.TaskTags = taskTags
+			}
+
+			detectIndent(&indents.Item, indent)
+			stats.updateWithTaskTags(taskTags)
+			continue
 		}
 
-		rows = append(rows, &Row{Data: Passthru(line)})
+		rows = append(rows, &Row{indent, pr.passthru(raw, line)})
+
+	}
+
+	// Failed read leaves the input incomplete, parse errors of its remainder aside
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
+	if len(errs) > 0 && !pr.isLenient {
+		return nil, errs
 	}
 
-	result := &Result{rows, stats}
+	result := &Result{rows, plays, stats, nil, indents, errs}
 
-	return result, scanner.Err()
+	return result, nil
 }
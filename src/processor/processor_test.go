@@ -6,6 +6,7 @@ package processor
 
 import (
 	"bufio"
+	"errors"
 	"strings"
 	"testing"
 
@@ -21,13 +22,13 @@ func Test_processPlay(t *testing.T) {
 			want  *Play
 		}{{
 			input: "play #1 (vps1): Test	TAGS: []",
-			want:  &Play{"play #1 (vps1): Test", "[]"},
+			want:  &Play{Name: "play #1 (vps1): Test", Number: 1, HostPattern: "vps1", Title: "Test", Tags: "[]"},
 		}, {
 			input: "play #1 (vps1): Test    TAGS:",
-			want:  &Play{"play #1 (vps1): Test", ""},
+			want:  &Play{Name: "play #1 (vps1): Test", Number: 1, HostPattern: "vps1", Title: "Test", Tags: ""},
 		}, {
 			input: "     play #1 (vps1): TestTAGS: [tag1,   tag2]     ",
-			want:  &Play{"play #1 (vps1): Test", "[tag1,   tag2]"},
+			want:  &Play{Name: "play #1 (vps1): Test", Number: 1, HostPattern: "vps1", Title: "Test", Tags: "[tag1,   tag2]", TagList: []string{"tag1", "tag2"}},
 		}, {
 			input: "TAGS:",
 			want:  &Play{},
@@ -72,6 +73,60 @@ func Test_processPlay(t *testing.T) {
 	})
 
 }
+func Test_parsePlayName(t *testing.T) {
+	type parsed struct {
+		Number      int
+		HostPattern string
+		Title       string
+		Ok          bool
+	}
+
+	tests := []struct {
+		input string
+		want  parsed
+	}{
+		{"play #1 (vps1): Test", parsed{1, "vps1", "Test", true}},
+		{"play #12 (web:&(staging)): Deploy (canary): step 1", parsed{12, "web:&(staging)", "Deploy (canary): step 1", true}},
+		{"play #3 (all):", parsed{3, "all", "", true}},
+		{"play #x (all): Test", parsed{}},
+		{"play #1 all: Test", parsed{}},
+		{"Test", parsed{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got parsed
+
+			got.Number, got.HostPattern, got.Title, got.Ok = parsePlayName(tt.input)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_calcIndent(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"play", 0},
+		{"  play", 2},
+		{"      ", 6},
+		{"  \tplay", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, calcIndent(tt.input)); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_processTask(t *testing.T) {
 	t.Run("Returns 'Task' struct", func(t *testing.T) {
 
@@ -80,13 +135,13 @@ func Test_processTask(t *testing.T) {
 			want  *Task
 		}{{
 			input: "Block: Name	TAGS: [♪, ♪♪, ♪♪♪]",
-			want:  &Task{"Block", "Name", "[♪, ♪♪, ♪♪♪]"},
+			want:  &Task{Block: "Block", Name: "Name", Tags: "[♪, ♪♪, ♪♪♪]", TagList: []string{"♪", "♪♪", "♪♪♪"}},
 		}, {
 			input: "Block NameTAGS: [♪,♪♪,♪♪♪]",
-			want:  &Task{"", "Block Name", "[♪,♪♪,♪♪♪]"},
+			want:  &Task{Block: "", Name: "Block Name", Tags: "[♪,♪♪,♪♪♪]", TagList: []string{"♪", "♪♪", "♪♪♪"}},
 		}, {
 			input: "Block NameTAGS:",
-			want:  &Task{"", "Block Name", ""},
+			want:  &Task{Block: "", Name: "Block Name", Tags: ""},
 		}, {
 			input: "TAGS:",
 			want:  &Task{},
@@ -132,6 +187,136 @@ func Test_processTask(t *testing.T) {
 
 }
 
+func Test_processTaskTags(t *testing.T) {
+	t.Run("Returns 'TaskTags' struct", func(t *testing.T) {
+
+		tests := []struct {
+			input string
+			want  *TaskTags
+		}{{
+			input: "      TASK TAGS: [tag1, tag2]",
+			want:  &TaskTags{PlayNumber: 1, Tags: "[tag1, tag2]", TagList: []string{"tag1", "tag2"}},
+		}, {
+			input: "TASK TAGS: []",
+			want:  &TaskTags{PlayNumber: 1, Tags: "[]"},
+		}, {
+			input: "TASK TAGS:",
+			want:  &TaskTags{PlayNumber: 1},
+		}}
+
+		for _, tt := range tests {
+			t.Run("", func(t *testing.T) {
+				got, err := processTaskTags(tt.input, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Returns error upon unexpected format", func(t *testing.T) {
+
+		tests := []struct {
+			input string
+			want  *TaskTags
+		}{{
+			input: "      TASK TAG: [tag1, tag2]",
+			want:  nil,
+		}, {
+			input: "      Name TASK TAGS: [tag1, tag2]",
+			want:  nil,
+		}}
+
+		for _, tt := range tests {
+			t.Run("", func(t *testing.T) {
+				got, err := processTaskTags(tt.input, 1)
+
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+			})
+		}
+	})
+}
+
+func Test_processHostsPattern(t *testing.T) {
+	t.Run("Returns 'Hosts' struct", func(t *testing.T) {
+		want := &Hosts{PlayNumber: 1, Pattern: "['web', 'db']"}
+		got, err := processHostsPattern("    pattern: ['web', 'db']  ", 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Returns error upon unexpected format", func(t *testing.T) {
+		got, err := processHostsPattern("    patterns ['web', 'db']", 1)
+
+		if diff := cmp.Diff((*Hosts)(nil), got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func Test_processHostsCount(t *testing.T) {
+	t.Run("Returns host count", func(t *testing.T) {
+		tests := []struct {
+			input string
+			want  int
+		}{
+			{"    hosts (0):", 0},
+			{"    hosts (2):", 2},
+			{"hosts (123):  ", 123},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				got, err := processHostsCount(tt.input)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Returns error upon unexpected format", func(t *testing.T) {
+		tests := []string{
+			"    hosts (two):",
+			"    hosts (2)",
+			"    hosts:",
+		}
+
+		for _, input := range tests {
+			t.Run(input, func(t *testing.T) {
+				_, err := processHostsCount(input)
+
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+			})
+		}
+	})
+}
+
 func TestProcessLines(t *testing.T) {
 
 	t.Run("Returns 'Result' struct", func(t *testing.T) {
@@ -149,30 +334,42 @@ func TestProcessLines(t *testing.T) {
 		ll.WriteLine("      Task 2.1	TAGS: []")
 		ll.WriteLine("      Task 2.2	TAGS: []")
 
+		tasks1 := &Tasks{1, []*Task{
+			{Block: "Block", Name: "Name", Tags: "[Tag1, Tag2]", TagList: []string{"Tag1", "Tag2"}, Line: 5},
+			{Block: "", Name: "Gather the package facts", Tags: "[apt, facts, vars]", TagList: []string{"apt", "facts", "vars"}, Line: 6},
+		}}
+		tasks2 := &Tasks{2, []*Task{
+			{Block: "", Name: "Task 2.1", Tags: "[]", Line: 10},
+			{Block: "", Name: "Task 2.2", Tags: "[]", Line: 11},
+		}}
+		play1 := &Play{Name: "play #1 (vps): Test", Number: 1, HostPattern: "vps", Title: "Test", Tags: "[]", Tasks: tasks1}
+		play2 := &Play{Name: "play #2 (vps): Demo 2", Number: 2, HostPattern: "vps", Title: "Demo 2", Tags: "[]", Tasks: tasks2}
+
 		want := &Result{
-			[]*Row{
+			Rows: []*Row{
 				{0, Passthru("playbook: playbooks/vsp/playbook_vps.yml")},
 				{0, Passthru("")},
-				{2, &Play{"play #1 (vps): Test", "[]"}},
-				{0, Passthru("    tasks:")},
-				{6, &Tasks{1, []*Task{
-					{"Block", "Name", "[Tag1, Tag2]"},
-					{"", "Gather the package facts", "[apt, facts, vars]"},
-				}}},
+				{2, play1},
+				{4, Passthru("    tasks:")},
+				{6, tasks1},
 				{0, Passthru("")},
-				{2, &Play{"play #2 (vps): Demo 2", "[]"}},
-				{0, Passthru("    tasks:")},
-				{6, &Tasks{2, []*Task{
-					{"", "Task 2.1", "[]"},
-					{"", "Task 2.2", "[]"},
-				}}},
+				{2, play2},
+				{4, Passthru("    tasks:")},
+				{6, tasks2},
 			},
-			&Stats{
+			Plays: []*Play{play1, play2},
+			Stats: &Stats{
 				Widther:                      cmn.RunesWidther{},
+				InputFormat:                  FormatList,
 				LongestPlayDescription:       "play #2 (vps): Demo 2",
 				LongestPlayDescriptionLength: 21,
 				LongestPlayTags:              "[]",
 				LongestPlayTagsLength:        2,
+				LongestPlayHostPattern:       "vps",
+				LongestPlayHostPatternLength: 3,
+				LongestPlayTitle:             "Demo 2",
+				LongestPlayTitleLength:       6,
+				MaxPlayNumber:                2,
 				LongestTaskBlock:             "Block",
 				LongestTaskBlockLength:       5,
 				LongestTaskName:              "Gather the package facts",
@@ -181,7 +378,286 @@ func TestProcessLines(t *testing.T) {
 				LongestTaskDescriptionLength: 24,
 				LongestTaskTags:              "[apt, facts, vars]",
 				LongestTaskTagsLength:        18,
+				TaskBlockLengthP90:           5,
+				TaskNameLengthP90:            24,
+				TaskTagsLengthP90:            18,
+				TasksCount:                   4,
+				MaxPlayTasksCount:            2,
+				MaxTaskTagsCount:             3,
+				MaxTaskLine:                  11,
+				LongestTag:                   "facts",
+				LongestTagLength:             5,
+			},
+			PlayStats: []*Stats{
+				{
+					Widther:                      cmn.RunesWidther{},
+					InputFormat:                  FormatList,
+					LongestPlayDescription:       "play #1 (vps): Test",
+					LongestPlayDescriptionLength: 19,
+					LongestPlayTags:              "[]",
+					LongestPlayTagsLength:        2,
+					LongestPlayHostPattern:       "vps",
+					LongestPlayHostPatternLength: 3,
+					LongestPlayTitle:             "Test",
+					LongestPlayTitleLength:       4,
+					MaxPlayNumber:                1,
+					LongestTaskBlock:             "Block",
+					LongestTaskBlockLength:       5,
+					LongestTaskName:              "Gather the package facts",
+					LongestTaskNameLength:        24,
+					LongestTaskDescription:       "Gather the package facts",
+					LongestTaskDescriptionLength: 24,
+					LongestTaskTags:              "[apt, facts, vars]",
+					LongestTaskTagsLength:        18,
+					TaskBlockLengthP90:           5,
+					TaskNameLengthP90:            24,
+					TaskTagsLengthP90:            18,
+					TasksCount:                   2,
+					MaxPlayTasksCount:            2,
+					MaxTaskTagsCount:             3,
+					MaxTaskLine:                  6,
+					LongestTag:                   "facts",
+					LongestTagLength:             5,
+				},
+				{
+					Widther:                      cmn.RunesWidther{},
+					InputFormat:                  FormatList,
+					LongestPlayDescription:       "play #2 (vps): Demo 2",
+					LongestPlayDescriptionLength: 21,
+					LongestPlayTags:              "[]",
+					LongestPlayTagsLength:        2,
+					LongestPlayHostPattern:       "vps",
+					LongestPlayHostPatternLength: 3,
+					LongestPlayTitle:             "Demo 2",
+					LongestPlayTitleLength:       6,
+					MaxPlayNumber:                2,
+					LongestTaskName:              "Task 2.1",
+					LongestTaskNameLength:        8,
+					LongestTaskDescription:       "Task 2.1",
+					LongestTaskDescriptionLength: 8,
+					LongestTaskTags:              "[]",
+					LongestTaskTagsLength:        2,
+					TaskNameLengthP90:            8,
+					TaskTagsLengthP90:            2,
+					TasksCount:                   2,
+					MaxPlayTasksCount:            2,
+					MaxTaskLine:                  11,
+				},
+			},
+			Indents: Indents{Play: 2, Section: 4, Item: 6},
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(ll.String()))
+		got, err := ProcessLines(scanner, cmn.RunesWidther{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Returns 'Result' struct for --list-tags output", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("playbook: playbooks/vsp/playbook_vps.yml")
+		ll.WriteLine("")
+		ll.WriteLine("  play #1 (vps): Test	TAGS: []")
+		ll.WriteLine("      TASK TAGS: [apt, facts, vars]")
+		ll.WriteLine("")
+		ll.WriteLine("  play #2 (vps): Demo 2	TAGS: [demo]")
+		ll.WriteLine("    tasks:")
+		ll.WriteLine("      Task 2.1	TAGS: [demo]")
+		ll.WriteLine("      TASK TAGS: [demo]")
+
+		taskTags1 := &TaskTags{PlayNumber: 1, Tags: "[apt, facts, vars]", TagList: []string{"apt", "facts", "vars"}}
+		taskTags2 := &TaskTags{PlayNumber: 2, Tags: "[demo]", TagList: []string{"demo"}}
+		tasks2 := &Tasks{2, []*Task{
+			{Block: "", Name: "Task 2.1", Tags: "[demo]", TagList: []string{"demo"}, Line: 8},
+		}}
+		play1 := &Play{Name: "play #1 (vps): Test", Number: 1, HostPattern: "vps", Title: "Test", Tags: "[]", TaskTags: taskTags1}
+		play2 := &Play{Name: "play #2 (vps): Demo 2", Number: 2, HostPattern: "vps", Title: "Demo 2", Tags: "[demo]", TagList: []string{"demo"}, Tasks: tasks2, TaskTags: taskTags2}
+
+		want := &Result{
+			Rows: []*Row{
+				{0, Passthru("playbook: playbooks/vsp/playbook_vps.yml")},
+				{0, Passthru("")},
+				{2, play1},
+				{6, taskTags1},
+				{0, Passthru("")},
+				{2, play2},
+				{4, Passthru("    tasks:")},
+				{6, tasks2},
+				{6, taskTags2},
+			},
+			Plays: []*Play{play1, play2},
+			Stats: &Stats{
+				Widther:                      cmn.RunesWidther{},
+				InputFormat:                  FormatList,
+				LongestPlayDescription:       "play #2 (vps): Demo 2",
+				LongestPlayDescriptionLength: 21,
+				LongestPlayTags:              "[demo]",
+				LongestPlayTagsLength:        6,
+				LongestPlayHostPattern:       "vps",
+				LongestPlayHostPatternLength: 3,
+				LongestPlayTitle:             "Demo 2",
+				LongestPlayTitleLength:       6,
+				MaxPlayNumber:                2,
+				LongestTaskName:              "Task 2.1",
+				LongestTaskNameLength:        8,
+				LongestTaskDescription:       "Task 2.1",
+				LongestTaskDescriptionLength: 8,
+				LongestTaskTags:              "[demo]",
+				LongestTaskTagsLength:        6,
+				TaskNameLengthP90:            8,
+				TaskTagsLengthP90:            6,
+				TasksCount:                   1,
+				MaxPlayTasksCount:            1,
+				MaxTaskTagsCount:             1,
+				MaxTaskLine:                  8,
+				LongestTag:                   "facts",
+				LongestTagLength:             5,
+			},
+			PlayStats: []*Stats{
+				{
+					Widther:                      cmn.RunesWidther{},
+					InputFormat:                  FormatList,
+					LongestPlayDescription:       "play #1 (vps): Test",
+					LongestPlayDescriptionLength: 19,
+					LongestPlayTags:              "[]",
+					LongestPlayTagsLength:        2,
+					LongestPlayHostPattern:       "vps",
+					LongestPlayHostPatternLength: 3,
+					LongestPlayTitle:             "Test",
+					LongestPlayTitleLength:       4,
+					MaxPlayNumber:                1,
+					LongestTag:                   "facts",
+					LongestTagLength:             5,
+				},
+				{
+					Widther:                      cmn.RunesWidther{},
+					InputFormat:                  FormatList,
+					LongestPlayDescription:       "play #2 (vps): Demo 2",
+					LongestPlayDescriptionLength: 21,
+					LongestPlayTags:              "[demo]",
+					LongestPlayTagsLength:        6,
+					LongestPlayHostPattern:       "vps",
+					LongestPlayHostPatternLength: 3,
+					LongestPlayTitle:             "Demo 2",
+					LongestPlayTitleLength:       6,
+					MaxPlayNumber:                2,
+					LongestTaskName:              "Task 2.1",
+					LongestTaskNameLength:        8,
+					LongestTaskDescription:       "Task 2.1",
+					LongestTaskDescriptionLength: 8,
+					LongestTaskTags:              "[demo]",
+					LongestTaskTagsLength:        6,
+					TaskNameLengthP90:            8,
+					TaskTagsLengthP90:            6,
+					TasksCount:                   1,
+					MaxPlayTasksCount:            1,
+					MaxTaskTagsCount:             1,
+					MaxTaskLine:                  8,
+					LongestTag:                   "demo",
+					LongestTagLength:             4,
+				},
+			},
+			Indents: Indents{Play: 2, Section: 4, Item: 6},
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(ll.String()))
+		got, err := ProcessLines(scanner, cmn.RunesWidther{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Returns 'Result' struct for --list-hosts output", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("playbook: playbooks/vsp/playbook_vps.yml")
+		ll.WriteLine("")
+		ll.WriteLine("  play #1 (vps): Test	TAGS: []")
+		ll.WriteLine("    pattern: ['vps']")
+		ll.WriteLine("    hosts (2):")
+		ll.WriteLine("      vps1.example.com")
+		ll.WriteLine("      vps2")
+		ll.WriteLine("")
+		ll.WriteLine("  play #2 (none): Demo 2	TAGS: []")
+		ll.WriteLine("    pattern: ['none']")
+		ll.WriteLine("    hosts (0):")
+
+		hosts1 := &Hosts{PlayNumber: 1, Pattern: "['vps']", Count: 2, Hosts: []string{"vps1.example.com", "vps2"}}
+		hosts2 := &Hosts{PlayNumber: 2, Pattern: "['none']", Count: 0}
+		play1 := &Play{Name: "play #1 (vps): Test", Number: 1, HostPattern: "vps", Title: "Test", Tags: "[]", Hosts: hosts1}
+		play2 := &Play{Name: "play #2 (none): Demo 2", Number: 2, HostPattern: "none", Title: "Demo 2", Tags: "[]", Hosts: hosts2}
+
+		want := &Result{
+			Rows: []*Row{
+				{0, Passthru("playbook: playbooks/vsp/playbook_vps.yml")},
+				{0, Passthru("")},
+				{2, play1},
+				{4, hosts1},
+				{0, Passthru("")},
+				{2, play2},
+				{4, hosts2},
+			},
+			Plays: []*Play{play1, play2},
+			Stats: &Stats{
+				Widther:                      cmn.RunesWidther{},
+				InputFormat:                  FormatList,
+				LongestPlayDescription:       "play #2 (none): Demo 2",
+				LongestPlayDescriptionLength: 22,
+				LongestPlayTags:              "[]",
+				LongestPlayTagsLength:        2,
+				LongestPlayHostPattern:       "none",
+				LongestPlayHostPatternLength: 4,
+				LongestPlayTitle:             "Demo 2",
+				LongestPlayTitleLength:       6,
+				MaxPlayNumber:                2,
+				LongestHost:                  "vps1.example.com",
+				LongestHostLength:            16,
+				HostsCount:                   2,
+			},
+			PlayStats: []*Stats{
+				{
+					Widther:                      cmn.RunesWidther{},
+					InputFormat:                  FormatList,
+					LongestPlayDescription:       "play #1 (vps): Test",
+					LongestPlayDescriptionLength: 19,
+					LongestPlayTags:              "[]",
+					LongestPlayTagsLength:        2,
+					LongestPlayHostPattern:       "vps",
+					LongestPlayHostPatternLength: 3,
+					LongestPlayTitle:             "Test",
+					LongestPlayTitleLength:       4,
+					MaxPlayNumber:                1,
+					LongestHost:                  "vps1.example.com",
+					LongestHostLength:            16,
+					HostsCount:                   2,
+				},
+				{
+					Widther:                      cmn.RunesWidther{},
+					InputFormat:                  FormatList,
+					LongestPlayDescription:       "play #2 (none): Demo 2",
+					LongestPlayDescriptionLength: 22,
+					LongestPlayTags:              "[]",
+					LongestPlayTagsLength:        2,
+					LongestPlayHostPattern:       "none",
+					LongestPlayHostPatternLength: 4,
+					LongestPlayTitle:             "Demo 2",
+					LongestPlayTitleLength:       6,
+					MaxPlayNumber:                2,
+				},
 			},
+			Indents: Indents{Play: 2, Section: 4, Item: 6},
 		}
 
 		scanner := bufio.NewScanner(strings.NewReader(ll.String()))
@@ -196,6 +672,170 @@ func TestProcessLines(t *testing.T) {
 		}
 	})
 
+	t.Run("Attaches sections to plays for combined output", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("playbook: playbooks/vsp/playbook_vps.yml")
+		ll.WriteLine("")
+		ll.WriteLine("  play #1 (vps): Test	TAGS: [web]")
+		ll.WriteLine("    pattern: ['vps']")
+		ll.WriteLine("    hosts (1):")
+		ll.WriteLine("      vps1")
+		ll.WriteLine("    tasks:")
+		ll.WriteLine("      Task 1.1	TAGS: [apt]")
+		ll.WriteLine("      TASK TAGS: [apt, web]")
+		ll.WriteLine("")
+		ll.WriteLine("  play #2 (none): Demo 2	TAGS: []")
+		ll.WriteLine("    pattern: ['none']")
+		ll.WriteLine("    hosts (0):")
+		ll.WriteLine("    tasks:")
+		ll.WriteLine("      TASK TAGS: []")
+
+		want := []*Play{
+			{
+				Name:        "play #1 (vps): Test",
+				Number:      1,
+				HostPattern: "vps",
+				Title:       "Test",
+				Tags:        "[web]",
+				TagList:     []string{"web"},
+				Hosts:       &Hosts{PlayNumber: 1, Pattern: "['vps']", Count: 1, Hosts: []string{"vps1"}},
+				Tasks: &Tasks{1, []*Task{
+					{Block: "", Name: "Task 1.1", Tags: "[apt]", TagList: []string{"apt"}, Line: 8},
+				}},
+				TaskTags: &TaskTags{PlayNumber: 1, Tags: "[apt, web]", TagList: []string{"apt", "web"}},
+			},
+			{
+				Name:        "play #2 (none): Demo 2",
+				Number:      2,
+				HostPattern: "none",
+				Title:       "Demo 2",
+				Tags:        "[]",
+				Hosts:       &Hosts{PlayNumber: 2, Pattern: "['none']", Count: 0},
+				Tasks:       &Tasks{PlayNumber: 2},
+				TaskTags:    &TaskTags{PlayNumber: 2, Tags: "[]"},
+			},
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(ll.String()))
+		got, err := ProcessLines(scanner, cmn.RunesWidther{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(want, got.Plays); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Tolerates different indent widths", func(t *testing.T) {
+		process := func(lines ...string) *Result {
+			var ll cmn.LineBuilder
+
+			for _, line := range lines {
+				ll.WriteLine(line)
+			}
+
+			result, err := ProcessLines(bufio.NewScanner(strings.NewReader(ll.String())), cmn.RunesWidther{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return result
+		}
+
+		want := process(
+			"playbook: playbooks/vsp/playbook_vps.yml",
+			"",
+			"  play #1 (vps): Test	TAGS: [web]",
+			"    pattern: ['vps']",
+			"    hosts (2):",
+			"      vps1",
+			"      vps2",
+			"    tasks:",
+			"      Task 1.1	TAGS: [apt]",
+			"      TASK TAGS: [apt, web]",
+		)
+
+		tests := []struct {
+			name    string
+			lines   []string
+			indents Indents
+		}{
+			{
+				name: "dedented",
+				lines: []string{
+					"playbook: playbooks/vsp/playbook_vps.yml",
+					"",
+					"play #1 (vps): Test	TAGS: [web]",
+					" pattern: ['vps']",
+					" hosts (2):",
+					"  vps1",
+					"  vps2",
+					" tasks:",
+					"  Task 1.1	TAGS: [apt]",
+					"  TASK TAGS: [apt, web]",
+				},
+				indents: Indents{Play: 0, Section: 1, Item: 2},
+			},
+			{
+				name: "wide and nested",
+				lines: []string{
+					"playbook: playbooks/vsp/playbook_vps.yml",
+					"",
+					"    play #1 (vps): Test	TAGS: [web]",
+					"        pattern: ['vps']",
+					"        hosts (2):",
+					"            vps1",
+					"                vps2",
+					"        tasks:",
+					"            Task 1.1	TAGS: [apt]",
+					"            TASK TAGS: [apt, web]",
+				},
+				indents: Indents{Play: 4, Section: 8, Item: 12},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got := process(tt.lines...)
+
+				if diff := cmp.Diff(want.Plays, got.Plays); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+
+				if diff := cmp.Diff(tt.indents, got.Indents); diff != "" {
+					t.Errorf("(-want +got): \n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Ends section upon a line that isn't indented deeper", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("  play #1 (vps): Test	TAGS: []")
+		ll.WriteLine("    hosts (1):")
+		ll.WriteLine("      vps1")
+		ll.WriteLine("    vps2")
+
+		got, err := ProcessLines(bufio.NewScanner(strings.NewReader(ll.String())), cmn.RunesWidther{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*Row{
+			{2, got.Plays[0]},
+			{4, &Hosts{PlayNumber: 1, Count: 1, Hosts: []string{"vps1"}}},
+			{4, Passthru("    vps2")},
+		}
+
+		if diff := cmp.Diff(want, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
 	t.Run("Returns error upon unexpected play format", func(t *testing.T) {
 		var ll cmn.LineBuilder
 		var want *Result
@@ -245,3 +885,249 @@ func TestProcessLines(t *testing.T) {
 	})
 
 }
+
+func TestProcessor(t *testing.T) {
+	var ll cmn.LineBuilder
+
+	ll.WriteLine("playbook: playbooks/vsp/playbook_vps.yml")
+	ll.WriteLine("")
+	ll.WriteLine("  play #1 (vps): Test	TAGS: []")
+	ll.WriteLine("    tasks:")
+	ll.WriteLine("      Task 1.1	TAG: []")
+	ll.WriteLine("      Task 1.2	TAGS: []")
+	ll.WriteLine("")
+	ll.WriteLine("  play #2 (vps): Demo 2	TAG: []")
+	ll.WriteLine("    hosts (x):")
+
+	input := ll.String()
+
+	wantErrs := ParseErrors{
+		{5, 7, "      Task 1.1  TAG: []", errors.New("processor.processTask: unexpected task format")},
+		{8, 3, "  play #2 (vps): Demo 2 TAG: []", errors.New("processor.processPlay: unexpected play format")},
+		{9, 5, "    hosts (x):", errors.New("processor.processHostsCount: unexpected hosts count format")},
+	}
+
+	errComparer := cmp.Comparer(func(x, y error) bool {
+		return x.Error() == y.Error()
+	})
+
+	t.Run("NewProcessor(): defaults", func(t *testing.T) {
+		want := &Processor{widther: cmn.RunesWidther{}, format: FormatList, sanitize: cmn.SanitizeEscape, tabStop: cmn.DefaultTabStop}
+		got := NewProcessor()
+
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(Processor{})); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Setters", func(t *testing.T) {
+		want := &Processor{widther: cmn.MonospaceWidther{}, format: FormatRun, sanitize: cmn.SanitizeCaret, tabStop: 4, isLenient: true, isKeepColors: true}
+		got := NewProcessor().SetWidther(cmn.MonospaceWidther{}).SetFormat(FormatRun).SetSanitize(cmn.SanitizeCaret).SetTabStop(4).SetIsLenient(true).SetIsKeepColors(true)
+
+		if diff := cmp.Diff(want, got, cmp.AllowUnexported(Processor{})); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Strict: reports all errors", func(t *testing.T) {
+		got, err := NewProcessor().Process(bufio.NewScanner(strings.NewReader(input)))
+
+		if got != nil {
+			t.Errorf("expected nil result")
+		}
+
+		var gotErrs ParseErrors
+		if !errors.As(err, &gotErrs) {
+			t.Fatalf("expected ParseErrors, got: %v", err)
+		}
+
+		if diff := cmp.Diff(wantErrs, gotErrs, errComparer); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Lenient: demotes malformed lines to passthru", func(t *testing.T) {
+		got, err := NewProcessor().SetIsLenient(true).Process(bufio.NewScanner(strings.NewReader(input)))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(wantErrs, got.Warnings, errComparer); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		tasks := &Tasks{1, []*Task{{Name: "Task 1.2", Tags: "[]", Line: 6}}}
+
+		wantRows := []*Row{
+			{0, Passthru("playbook: playbooks/vsp/playbook_vps.yml")},
+			{0, Passthru("")},
+			{2, got.Plays[0]},
+			{4, Passthru("    tasks:")},
+			{6, tasks},
+			{6, Passthru("      Task 1.1  TAG: []")},
+			{0, Passthru("")},
+			{2, Passthru("  play #2 (vps): Demo 2 TAG: []")},
+			{4, Passthru("    hosts (x):")},
+		}
+
+		if diff := cmp.Diff(wantRows, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if diff := cmp.Diff(1, len(got.Plays)); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Lenient: keeps sections of a malformed play as passthru", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("  play #1 (vps): Demo 1	TAG: []")
+		ll.WriteLine("    tasks:")
+		ll.WriteLine("      Task 1.1	TAGS: []")
+		ll.WriteLine("  play #2 (vps): Demo 2	TAGS: []")
+		ll.WriteLine("    tasks:")
+		ll.WriteLine("      Task 2.1	TAGS: []")
+
+		got, err := NewProcessor().SetIsLenient(true).Process(bufio.NewScanner(strings.NewReader(ll.String())))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tasks := &Tasks{2, []*Task{{Name: "Task 2.1", Tags: "[]", Line: 6}}}
+
+		wantRows := []*Row{
+			{2, Passthru("  play #1 (vps): Demo 1 TAG: []")},
+			{4, Passthru("    tasks:")},
+			{6, Passthru("      Task 1.1  TAGS: []")},
+			{2, got.Plays[0]},
+			{4, Passthru("    tasks:")},
+			{6, tasks},
+		}
+
+		if diff := cmp.Diff(wantRows, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		// Orphan task counts into no play
+		if diff := cmp.Diff(1, got.Stats.TasksCount); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Strict: read error takes precedence over parse errors", func(t *testing.T) {
+		scanner := bufio.NewScanner(strings.NewReader("  play #1 (vps): Test\n" + strings.Repeat("x", 64) + "\n"))
+		scanner.Buffer(nil, 32)
+
+		_, err := NewProcessor().Process(scanner)
+
+		if !errors.Is(err, bufio.ErrTooLong) {
+			t.Errorf("expected bufio.ErrTooLong, got: %v", err)
+		}
+	})
+
+	t.Run("Play header deeper than the current play is passthru", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("  play #1 (vps): Demo 1	TAGS: []")
+		ll.WriteLine("      TASK TAGS: []")
+		ll.WriteLine("      play #2 (vps): Demo 2	TAGS: []")
+
+		got, err := NewProcessor().Process(bufio.NewScanner(strings.NewReader(ll.String())))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(1, len(got.Plays)); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if diff := cmp.Diff(&Row{6, Passthru("      play #2 (vps): Demo 2     TAGS: []")}, got.Rows[2]); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Empty tasks section is indented as detected items", func(t *testing.T) {
+		var ll cmn.LineBuilder
+
+		ll.WriteLine("play #1 (vps): Demo 1	TAGS: []")
+		ll.WriteLine("    tasks:")
+		ll.WriteLine("        Task 1.1	TAGS: []")
+		ll.WriteLine("play #2 (vps): Demo 2	TAGS: []")
+		ll.WriteLine("    tasks:")
+
+		got, err := NewProcessor().Process(bufio.NewScanner(strings.NewReader(ll.String())))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(8, got.Rows[len(got.Rows)-1].Indent); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Strips escape sequences before parsing", func(t *testing.T) {
+		colored := "\x1b[0;32mplaybook: site.yml\x1b[0m\n\n\x1b[1m  play #1 (vps): Test\tTAGS: []\x1b[0m\n    tasks:\n\x1b[0;33m      Task 1.1\tTAGS: []\x1b[0m\n"
+
+		fnRows := func(pr *Processor) []*Row {
+			got, err := pr.Process(bufio.NewScanner(strings.NewReader(colored)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return got.Rows[:2]
+		}
+
+		want := []*Row{{0, Passthru("playbook: site.yml")}, {0, Passthru("")}}
+
+		if diff := cmp.Diff(want, fnRows(NewProcessor())); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		want = []*Row{{0, Passthru("\x1b[0;32mplaybook: site.yml\x1b[0m")}, {0, Passthru("")}}
+
+		if diff := cmp.Diff(want, fnRows(NewProcessor().SetIsKeepColors(true))); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Sanitizes control characters", func(t *testing.T) {
+		input := "playbook: site.yml\n\n  play #1 (vps): Te\x07st\tTAGS: []\n    tasks:\n      Task\r 1.1\tTAGS: []\n"
+
+		got, err := NewProcessor().SetSanitize(cmn.SanitizePicture).Process(bufio.NewScanner(strings.NewReader(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"Te␇st", "Task␍ 1.1"}
+
+		if diff := cmp.Diff(want, []string{got.Plays[0].Title, got.Plays[0].Tasks.Tasks[0].Name}); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		// Stats account for sanitized values
+		if diff := cmp.Diff(9, got.Stats.LongestTaskNameLength); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Expands tabs", func(t *testing.T) {
+		input := "playbook: site.yml\n\n  play #1 (vps): Test\tTAGS: []\n    tasks:\n      Task\t1.1\tTAGS: []\n\tpassthru\n"
+
+		got, err := NewProcessor().SetTabStop(4).Process(bufio.NewScanner(strings.NewReader(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"Task  1.1", "    passthru"}
+
+		if diff := cmp.Diff(want, []string{got.Plays[0].Tasks.Tasks[0].Name, got.Rows[5].Data.String()}); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if diff := cmp.Diff(9, got.Stats.LongestTaskNameLength); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,361 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Host statuses of run output
+const (
+	StatusOk          = "ok"
+	StatusChanged     = "changed"
+	StatusSkipping    = "skipping"
+	StatusFailed      = "failed"
+	StatusFatal       = "fatal"
+	StatusUnreachable = "unreachable"
+	StatusIncluded    = "included"
+)
+
+// Kinds of run output task sections
+const (
+	KindTask           = "TASK"
+	KindRunningHandler = "RUNNING HANDLER"
+)
+
+const kindPlay = "PLAY"
+const kindPlayRecap = "PLAY RECAP"
+
+// RunPlay is a `PLAY [name]` header of run output
+type RunPlay struct {
+	Number int
+	Name   string
+}
+
+func (rp *RunPlay) String() string {
+	return fmt.Sprintf("PLAY [%s]", rp.Name)
+}
+
+// HostResult is a `changed: [host]` line of run output
+type HostResult struct {
+	Host    string
+	Status  string // See Status* constants
+	Item    string // Loop item, e.g. "foo" of `(item=foo)`
+	Message string // Text after `=>`, e.g. a JSON result of failed task
+}
+
+// RunTask is a `TASK [role : name]` section of run output along with its host lines
+type RunTask struct {
+	PlayNumber int
+	Kind       string // See Kind* constants
+	Block      string
+	Name       string
+	Results    []*HostResult
+}
+
+func (rt *RunTask) Description() string {
+	if rt.Block == "" {
+		return rt.Name
+	}
+
+	return rt.Block + ": " + rt.Name
+}
+
+func (rt *RunTask) String() string {
+	if rt.Block == "" {
+		return fmt.Sprintf("%s [%s]", rt.Kind, rt.Name)
+	}
+
+	return fmt.Sprintf("%s [%s : %s]", rt.Kind, rt.Block, rt.Name)
+}
+
+func (rt *RunTask) Add(hr *HostResult) {
+	rt.Results = append(rt.Results, hr)
+}
+
+// RecapHost is a `host : ok=1 changed=0 ...` line of the `PLAY RECAP` section
+type RecapHost struct {
+	Host        string
+	Ok          int
+	Changed     int
+	Unreachable int
+	Failed      int
+	Skipped     int
+	Rescued     int
+	Ignored     int
+}
+
+// Recap is a `PLAY RECAP` section of run output
+type Recap struct {
+	Hosts []*RecapHost
+}
+
+func (r *Recap) String() string {
+	return kindPlayRecap
+}
+
+func (r *Recap) Add(rh *RecapHost) {
+	r.Hosts = append(r.Hosts, rh)
+}
+
+// Total sums counters of all hosts
+func (r *Recap) Total() *RecapHost {
+	total := &RecapHost{}
+
+	for _, rh := range r.Hosts {
+		total.Ok += rh.Ok
+		total.Changed += rh.Changed
+		total.Unreachable += rh.Unreachable
+		total.Failed += rh.Failed
+		total.Skipped += rh.Skipped
+		total.Rescued += rh.Rescued
+		total.Ignored += rh.Ignored
+	}
+
+	return total
+}
+
+// processRunHeader splits `TASK [role : name] *****` into its kind and title
+func processRunHeader(text string) (kind string, title string, ok bool) {
+	value := strings.TrimRight(text, "* ")
+
+	if value == kindPlayRecap {
+		return kindPlayRecap, "", true
+	}
+
+	for _, k := range []string{kindPlay, KindTask, KindRunningHandler} {
+		if strings.HasPrefix(value, k+" [") && strings.HasSuffix(value, "]") {
+			return k, value[len(k)+2 : len(value)-1], true
+		}
+	}
+
+	return "", "", false
+}
+
+func isHostStatus(value string) bool {
+	switch value {
+	case StatusOk, StatusChanged, StatusSkipping, StatusFailed, StatusFatal, StatusIncluded:
+		return true
+	}
+
+	return false
+}
+
+// processHostResult parses `status: [host]...` lines. `included: file for host1, host2`
+// results in a line per host; a loop item follows the hosts: `... for host1 => (item=foo)`.
+func processHostResult(text string) ([]*HostResult, error) {
+	status, rest, found := strings.Cut(text, ": ")
+
+	if !found || !isHostStatus(status) {
+		return nil, errors.New("processor.processHostResult: unexpected host result format")
+	}
+
+	if status == StatusIncluded {
+		file, hosts, found := strings.Cut(rest, " for ")
+		if !found {
+			return nil, errors.New("processor.processHostResult: unexpected include format")
+		}
+
+		hosts, details, _ := strings.Cut(hosts, " => ")
+		item := parseResultItem(details)
+		results := make([]*HostResult, 0, 1)
+
+		for _, host := range strings.Split(hosts, ",") {
+			results = append(results, &HostResult{Host: strings.TrimSpace(host), Status: status, Item: item, Message: file})
+		}
+
+		return results, nil
+	}
+
+	if !strings.HasPrefix(rest, "[") || !strings.Contains(rest, "]") {
+		return nil, errors.New("processor.processHostResult: unexpected host format")
+	}
+
+	host, rest, _ := strings.Cut(rest[1:], "]")
+	result := &HostResult{Host: host, Status: status}
+
+	if strings.HasPrefix(rest, ": UNREACHABLE!") {
+		result.Status = StatusUnreachable
+	}
+
+	details, message, _ := strings.Cut(rest, "=> ")
+	result.Message = strings.TrimSpace(message)
+
+	// Item is either before or right after `=>`: `(item=foo) => {...}` or `=> (item=foo)`
+	if !strings.Contains(details, "(item=") && strings.HasPrefix(result.Message, "(item=") {
+		details, result.Message = result.Message, ""
+	}
+
+	result.Item = parseResultItem(details)
+
+	return []*HostResult{result}, nil
+}
+
+// parseResultItem returns the loop item of `(item=foo)` in details, if any
+func parseResultItem(details string) string {
+	_, item, found := strings.Cut(details, "(item=")
+	if !found {
+		return ""
+	}
+
+	if idx := strings.LastIndex(item, ")"); idx >= 0 {
+		item = item[:idx]
+	}
+
+	return strings.TrimSpace(item)
+}
+
+// processRecapHost parses `host : ok=1 changed=0 unreachable=0 failed=0 ...`
+func processRecapHost(text string) (*RecapHost, error) {
+	idx := strings.LastIndex(text, " : ")
+	if idx < 0 {
+		return nil, errors.New("processor.processRecapHost: unexpected recap format")
+	}
+
+	rh := &RecapHost{Host: strings.TrimSpace(text[:idx])}
+
+	counters := map[string]*int{
+		"ok":          &rh.Ok,
+		"changed":     &rh.Changed,
+		"unreachable": &rh.Unreachable,
+		"failed":      &rh.Failed,
+		"skipped":     &rh.Skipped,
+		"rescued":     &rh.Rescued,
+		"ignored":     &rh.Ignored,
+	}
+
+	for _, field := range strings.Fields(text[idx+3:]) {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return nil, errors.New("processor.processRecapHost: unexpected recap format")
+		}
+
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("processor.processRecapHost: unexpected recap format")
+		}
+
+		// Unknown counters of future ansible versions are skipped
+		if counter, ok := counters[key]; ok {
+			*counter = count
+		}
+	}
+
+	return rh, nil
+}
+
+// processRun parses run output of the default stdout callback. Lines that aren't headers,
+// host results or recap lines, e.g. warnings or verbose output, are kept as passthru.
+func (pr *Processor) processRun(scanner *bufio.Scanner) (*Result, error) {
+	var (
+		task  *RunTask
+		recap *Recap
+		errs  ParseErrors
+	)
+
+	rows := make([]*Row, 0, 2)
+	stats := &Stats{Widther: pr.widther}
+
+	lineNumber := 0
+	raw := ""
+	playsCount := 0
+
+	fnFail := func(line string, indent int, err error) {
+		errs = append(errs, &ParseError{lineNumber, indent + 1, line, err})
+		rows = append(rows, &Row{indent, pr.passthru(raw, line)})
+	}
+
+	for scanner.Scan() {
+		raw = scanner.Text()
+		line := pr.clean(raw)
+		text := strings.TrimSpace(line)
+		indent := calcIndent(line)
+
+		lineNumber++
+
+		if kind, title, ok := processRunHeader(text); ok {
+			task = nil
+			recap = nil
+
+			switch kind {
+			case kindPlay:
+				playsCount++
+				play := &RunPlay{playsCount, title}
+				rows = append(rows, &Row{indent, play})
+
+				stats.updateWithRunPlay(play)
+
+			case kindPlayRecap:
+				recap = &Recap{}
+				rows = append(rows, &Row{indent, recap})
+
+			default:
+				block, name, found := strings.Cut(title, " : ")
+				if !found {
+					block, name = "", title
+				}
+
+				task = &RunTask{PlayNumber: playsCount, Kind: kind, Block: block, Name: name}
+				rows = append(rows, &Row{indent, task})
+
+				stats.updateWithRunTask(task)
+			}
+
+			continue
+		}
+
+		if recap != nil && text != "" {
+			rh, err := processRecapHost(text)
+			if err != nil {
+				fnFail(line, indent, err)
+				continue
+			}
+
+			recap.Add(rh)
+
+			stats.updateWithRecapHost(rh)
+			continue
+		}
+
+		recap = nil
+
+		if task != nil {
+			if status, _, _ := strings.Cut(text, ": "); isHostStatus(status) {
+				results, err := processHostResult(text)
+				if err != nil {
+					fnFail(line, indent, err)
+					continue
+				}
+
+				for _, hr := range results {
+					task.Add(hr)
+
+					stats.updateHost(hr.Host)
+				}
+
+				continue
+			}
+		}
+
+		rows = append(rows, &Row{indent, pr.passthru(raw, line)})
+	}
+
+	// Failed read leaves the input incomplete, parse errors of its remainder aside
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(errs) > 0 && !pr.isLenient {
+		return nil, errs
+	}
+
+	result := &Result{rows, []*Play{}, stats, nil, Indents{-1, -1, -1}, errs}
+
+	return result, nil
+}
@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keewek/ansible-pretty-print/src/cmn"
+)
+
+func Test_processRunHeader(t *testing.T) {
+	type result struct {
+		Kind  string
+		Title string
+		Ok    bool
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want result
+	}{
+		{"play", "PLAY [Web servers] ****", result{kindPlay, "Web servers", true}},
+		{"task", "TASK [nginx : Install nginx] ****", result{KindTask, "nginx : Install nginx", true}},
+		{"handler", "RUNNING HANDLER [Restart nginx] ****", result{KindRunningHandler, "Restart nginx", true}},
+		{"recap", "PLAY RECAP ****", result{kindPlayRecap, "", true}},
+		{"no stars", "TASK [Ping]", result{KindTask, "Ping", true}},
+		{"not a header", "ok: [web01]", result{}},
+		{"unknown kind", "HANDLER [Restart nginx] ****", result{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got result
+
+			got.Kind, got.Title, got.Ok = processRunHeader(tt.text)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_processHostResult(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []*HostResult
+	}{
+		{
+			name: "ok",
+			text: "ok: [web01]",
+			want: []*HostResult{{Host: "web01", Status: StatusOk}},
+		},
+		{
+			name: "changed, item",
+			text: "changed: [web01] => (item=git)",
+			want: []*HostResult{{Host: "web01", Status: StatusChanged, Item: "git"}},
+		},
+		{
+			name: "failed, item, message",
+			text: `failed: [web01] (item=git) => {"msg": "No package"}`,
+			want: []*HostResult{{Host: "web01", Status: StatusFailed, Item: "git", Message: `{"msg": "No package"}`}},
+		},
+		{
+			name: "fatal",
+			text: `fatal: [web01]: FAILED! => {"msg": "boom"}`,
+			want: []*HostResult{{Host: "web01", Status: StatusFatal, Message: `{"msg": "boom"}`}},
+		},
+		{
+			name: "unreachable",
+			text: `fatal: [web01]: UNREACHABLE! => {"unreachable": true}`,
+			want: []*HostResult{{Host: "web01", Status: StatusUnreachable, Message: `{"unreachable": true}`}},
+		},
+		{
+			name: "included",
+			text: "included: /tasks/extra.yml for web01, web02",
+			want: []*HostResult{
+				{Host: "web01", Status: StatusIncluded, Message: "/tasks/extra.yml"},
+				{Host: "web02", Status: StatusIncluded, Message: "/tasks/extra.yml"},
+			},
+		},
+		{
+			name: "included, item",
+			text: "included: /r/t.yml for web01, web02 => (item=foo)",
+			want: []*HostResult{
+				{Host: "web01", Status: StatusIncluded, Item: "foo", Message: "/r/t.yml"},
+				{Host: "web02", Status: StatusIncluded, Item: "foo", Message: "/r/t.yml"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := processHostResult(tt.text)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+
+	t.Run("returns error upon unexpected format", func(t *testing.T) {
+		for _, text := range []string{"ok web01", "ok: web01", "included: /tasks/extra.yml"} {
+			if _, err := processHostResult(text); err == nil {
+				t.Errorf("expected error for %q", text)
+			}
+		}
+	})
+}
+
+func Test_processRecapHost(t *testing.T) {
+	got, err := processRecapHost("web01  : ok=5  changed=3  unreachable=1  failed=0  skipped=2  rescued=0  ignored=1  future=7")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &RecapHost{Host: "web01", Ok: 5, Changed: 3, Unreachable: 1, Skipped: 2, Ignored: 1}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+
+	for _, text := range []string{"web01 ok=5", "web01 : ok=five", "web01 : ok"} {
+		if _, err := processRecapHost(text); err == nil {
+			t.Errorf("expected error for %q", text)
+		}
+	}
+}
+
+func TestRecap_Total(t *testing.T) {
+	recap := &Recap{Hosts: []*RecapHost{
+		{Host: "web01", Ok: 5, Changed: 3, Skipped: 1},
+		{Host: "web02", Ok: 2, Unreachable: 1, Failed: 1, Rescued: 1, Ignored: 2},
+	}}
+
+	want := &RecapHost{Ok: 7, Changed: 3, Unreachable: 1, Failed: 1, Skipped: 1, Rescued: 1, Ignored: 2}
+
+	if diff := cmp.Diff(want, recap.Total()); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
+}
+
+func TestProcessor_processRun(t *testing.T) {
+	input := `
+PLAY [Web servers] ****
+
+TASK [nginx : Install nginx] ****
+changed: [web01]
+[WARNING]: something
+ok: [web02]
+
+RUNNING HANDLER [Restart nginx] ****
+changed: [web01]
+
+PLAY RECAP ****
+web01  : ok=2  changed=2  unreachable=0  failed=0  skipped=0  rescued=0  ignored=0
+web02  : ok=1  changed=0  unreachable=0  failed=0  skipped=0  rescued=0  ignored=0
+`
+
+	fnProcess := func(pr *Processor, input string) (*Result, error) {
+		return pr.SetFormat(FormatRun).Process(bufio.NewScanner(strings.NewReader(input)))
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		got, err := fnProcess(NewProcessor(), input)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []*Row{
+			{0, Passthru("")},
+			{0, &RunPlay{1, "Web servers"}},
+			{0, Passthru("")},
+			{0, &RunTask{PlayNumber: 1, Kind: KindTask, Block: "nginx", Name: "Install nginx", Results: []*HostResult{
+				{Host: "web01", Status: StatusChanged},
+				{Host: "web02", Status: StatusOk},
+			}}},
+			{0, Passthru("[WARNING]: something")},
+			{0, Passthru("")},
+			{0, &RunTask{PlayNumber: 1, Kind: KindRunningHandler, Name: "Restart nginx", Results: []*HostResult{
+				{Host: "web01", Status: StatusChanged},
+			}}},
+			{0, Passthru("")},
+			{0, &Recap{Hosts: []*RecapHost{
+				{Host: "web01", Ok: 2, Changed: 2},
+				{Host: "web02", Ok: 1},
+			}}},
+		}
+
+		if diff := cmp.Diff(want, got.Rows); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if diff := cmp.Diff(Indents{-1, -1, -1}, got.Indents); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		got, _ := fnProcess(NewProcessor(), input)
+
+		want := &Stats{
+			Widther:                      cmn.RunesWidther{},
+			InputFormat:                  FormatRun,
+			LongestPlayDescription:       "PLAY [Web servers]",
+			LongestPlayDescriptionLength: 18,
+			LongestPlayTitle:             "Web servers",
+			LongestPlayTitleLength:       11,
+			MaxPlayNumber:                1,
+			LongestTaskBlock:             "nginx",
+			LongestTaskBlockLength:       5,
+			LongestTaskName:              "Install nginx",
+			LongestTaskNameLength:        13,
+			LongestTaskDescription:       "nginx: Install nginx",
+			LongestTaskDescriptionLength: 20,
+			LongestHost:                  "web01",
+			LongestHostLength:            5,
+			HostsCount:                   2,
+		}
+
+		if diff := cmp.Diff(want, got.Stats); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("strict: returns parse errors", func(t *testing.T) {
+		_, err := fnProcess(NewProcessor(), "TASK [Ping] ****\nok: web01\n")
+
+		var errs ParseErrors
+
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Line != 2 {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("lenient: keeps unparsed lines as passthru", func(t *testing.T) {
+		got, err := fnProcess(NewProcessor().SetIsLenient(true), "TASK [Ping] ****\nok: web01\n")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(Passthru("ok: web01"), got.Rows[1].Data); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+
+		if len(got.Warnings) != 1 {
+			t.Errorf("expected 1 warning, got %d", len(got.Warnings))
+		}
+	})
+}
@@ -14,12 +14,21 @@ import (
 	"github.com/keewek/ansible-pretty-print/src/cmn"
 )
 
+// Percentile of task field widths deemed typical, see Stats.updatePercentiles
+const typicalPercentile = 90
+
 type Stats struct {
 	Widther                      cmn.Widther
+	InputFormat                  string
 	LongestPlayDescription       string
 	LongestPlayDescriptionLength int
 	LongestPlayTags              string
 	LongestPlayTagsLength        int
+	LongestPlayHostPattern       string
+	LongestPlayHostPatternLength int
+	LongestPlayTitle             string
+	LongestPlayTitleLength       int
+	MaxPlayNumber                int
 	LongestTaskBlock             string
 	LongestTaskBlockLength       int
 	LongestTaskName              string
@@ -28,6 +37,18 @@ type Stats struct {
 	LongestTaskDescriptionLength int
 	LongestTaskTags              string
 	LongestTaskTagsLength        int
+	TaskBlockLengthP90           int
+	TaskNameLengthP90            int
+	TaskTagsLengthP90            int
+	TasksCount                   int
+	MaxPlayTasksCount            int
+	MaxTaskTagsCount             int
+	MaxTaskLine                  int
+	LongestTag                   string
+	LongestTagLength             int
+	LongestHost                  string
+	LongestHostLength            int
+	HostsCount                   int
 }
 
 func (st *Stats) updatePlayDescription(value string) {
@@ -48,6 +69,28 @@ func (st *Stats) updatePlayTags(value string) {
 	}
 }
 
+func (st *Stats) updatePlayHostPattern(value string) {
+	hostPatternLength := st.Widther.Width(value)
+
+	if st.LongestPlayHostPatternLength < hostPatternLength {
+		st.LongestPlayHostPatternLength = hostPatternLength
+		st.LongestPlayHostPattern = value
+	}
+}
+
+func (st *Stats) updatePlayTitle(value string) {
+	titleLength := st.Widther.Width(value)
+
+	if st.LongestPlayTitleLength < titleLength {
+		st.LongestPlayTitleLength = titleLength
+		st.LongestPlayTitle = value
+	}
+}
+
+func (st *Stats) updatePlayNumber(value int) {
+	st.MaxPlayNumber = cmn.Max(st.MaxPlayNumber, value)
+}
+
 func (st *Stats) updateTaskBlock(value string) {
 	blockLength := st.Widther.Width(value)
 
@@ -84,9 +127,53 @@ func (st *Stats) updateTaskTags(value string) {
 	}
 }
 
+func (st *Stats) updateTaskTagsCount(value int) {
+	st.MaxTaskTagsCount = cmn.Max(st.MaxTaskTagsCount, value)
+}
+
+func (st *Stats) updateTaskLine(value int) {
+	st.MaxTaskLine = cmn.Max(st.MaxTaskLine, value)
+}
+
+func (st *Stats) updatePlayTasksCount(value int) {
+	st.MaxPlayTasksCount = cmn.Max(st.MaxPlayTasksCount, value)
+}
+
+func (st *Stats) updateTag(value string) {
+	tagLength := st.Widther.Width(value)
+
+	if st.LongestTagLength < tagLength {
+		st.LongestTagLength = tagLength
+		st.LongestTag = value
+	}
+}
+
+func (st *Stats) updateTagList(values []string) {
+	for _, value := range values {
+		st.updateTag(value)
+	}
+}
+
+func (st *Stats) updateHost(value string) {
+	hostLength := st.Widther.Width(value)
+
+	if st.LongestHostLength < hostLength {
+		st.LongestHostLength = hostLength
+		st.LongestHost = value
+	}
+}
+
+func (st *Stats) updateHostsCount(value int) {
+	st.HostsCount += value
+}
+
 func (st *Stats) updateWithPlay(pl *Play) {
 	st.updatePlayDescription(pl.Description())
 	st.updatePlayTags(pl.Tags)
+	st.updatePlayHostPattern(pl.HostPattern)
+	st.updatePlayTitle(pl.Title)
+	st.updatePlayNumber(pl.Number)
+	st.updateTagList(pl.TagList)
 }
 
 func (st *Stats) updateWithTask(t *Task) {
@@ -94,6 +181,136 @@ func (st *Stats) updateWithTask(t *Task) {
 	st.updateTaskName(t.Name)
 	st.updateTaskDescription(t.Description())
 	st.updateTaskTags(t.Tags)
+	st.updateTagList(t.TagList)
+	st.updateTaskTagsCount(len(t.TagList))
+	st.updateTaskLine(t.Line)
+	st.TasksCount++
+}
+
+func (st *Stats) updateWithRunPlay(rp *RunPlay) {
+	st.updatePlayDescription(rp.String())
+	st.updatePlayTitle(rp.Name)
+	st.updatePlayNumber(rp.Number)
+}
+
+func (st *Stats) updateWithRunTask(rt *RunTask) {
+	st.updateTaskBlock(rt.Block)
+	st.updateTaskName(rt.Name)
+	st.updateTaskDescription(rt.Description())
+}
+
+func (st *Stats) updateWithRecapHost(rh *RecapHost) {
+	st.updateHost(rh.Host)
+	st.updateHostsCount(1)
+}
+
+func (st *Stats) updateWithTaskTags(tt *TaskTags) {
+	st.updateTagList(tt.TagList)
+}
+
+func (st *Stats) updateWithHosts(hs *Hosts) {
+	st.updateHostsCount(hs.Count)
+
+	for _, host := range hs.Hosts {
+		st.updateHost(host)
+	}
+}
+
+func (st *Stats) updateWithRow(row *Row) {
+	switch t := row.Data.(type) {
+	case *Play:
+		st.updateWithPlay(t)
+
+	case *Tasks:
+		for _, task := range t.Tasks {
+			st.updateWithTask(task)
+		}
+
+		st.updatePlayTasksCount(len(t.Tasks))
+
+	case *TaskTags:
+		st.updateWithTaskTags(t)
+
+	case *Hosts:
+		st.updateWithHosts(t)
+
+	case *RunPlay:
+		st.updateWithRunPlay(t)
+
+	case *RunTask:
+		st.updateWithRunTask(t)
+
+		for _, hr := range t.Results {
+			st.updateHost(hr.Host)
+		}
+
+	case *Recap:
+		for _, rh := range t.Hosts {
+			st.updateWithRecapHost(rh)
+		}
+	}
+}
+
+// updatePercentiles calculates typical widths of task fields of listings. Unlike the
+// longest ones, these can't be updated value by value, so they're calculated over all
+// rows at once.
+func (st *Stats) updatePercentiles(rows []*Row) {
+	var blocks, names, tags []int
+
+	for _, row := range rows {
+		if t, ok := row.Data.(*Tasks); ok {
+			for _, task := range t.Tasks {
+				blocks = append(blocks, st.Widther.Width(task.Block))
+				names = append(names, st.Widther.Width(task.Name))
+				tags = append(tags, st.Widther.Width(task.Tags))
+			}
+		}
+	}
+
+	st.TaskBlockLengthP90 = cmn.Percentile(blocks, typicalPercentile)
+	st.TaskNameLengthP90 = cmn.Percentile(names, typicalPercentile)
+	st.TaskTagsLengthP90 = cmn.Percentile(tags, typicalPercentile)
+}
+
+// collectPlayStats collects Stats of each play separately, one per play row, *Play or
+// *RunPlay, in the order of appearance. Stats of a play cover its rows up to the next play
+// or `PLAY RECAP`. Rows preceding the first play count toward no play.
+func collectPlayStats(rows []*Row, global *Stats) []*Stats {
+	var (
+		all     []*Stats
+		current *Stats
+		first   int
+	)
+
+	fnFinish := func(last int) {
+		if current != nil {
+			current.updatePercentiles(rows[first:last])
+		}
+	}
+
+	for i, row := range rows {
+		switch row.Data.(type) {
+		case *Play, *RunPlay:
+			fnFinish(i)
+
+			current = &Stats{Widther: global.Widther, InputFormat: global.InputFormat}
+			first = i
+			all = append(all, current)
+
+		case *Recap:
+			fnFinish(i)
+
+			current = nil
+		}
+
+		if current != nil {
+			current.updateWithRow(row)
+		}
+	}
+
+	fnFinish(len(rows))
+
+	return all
 }
 
 func (st *Stats) Lines() []string {
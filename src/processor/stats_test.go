@@ -50,6 +50,59 @@ func Test_Stats(t *testing.T) {
 		}
 	})
 
+	t.Run("updatePlayHostPattern():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:                      cmn.RunesWidther{},
+			LongestPlayHostPattern:       "♪♪♪",
+			LongestPlayHostPatternLength: 3,
+		}
+
+		got.updatePlayHostPattern("♪♪♪")
+		got.updatePlayHostPattern("♪♪")
+		got.updatePlayHostPattern("♪")
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("updatePlayTitle():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:                cmn.RunesWidther{},
+			LongestPlayTitle:       "♪♪♪",
+			LongestPlayTitleLength: 3,
+		}
+
+		got.updatePlayTitle("♪♪♪")
+		got.updatePlayTitle("♪♪")
+		got.updatePlayTitle("♪")
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("updatePlayNumber():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:       cmn.RunesWidther{},
+			MaxPlayNumber: 12,
+		}
+
+		got.updatePlayNumber(3)
+		got.updatePlayNumber(12)
+		got.updatePlayNumber(5)
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
 	t.Run("updateBlock():", func(t *testing.T) {
 
 		got := Stats{Widther: cmn.RunesWidther{}}
@@ -122,6 +175,76 @@ func Test_Stats(t *testing.T) {
 		}
 	})
 
+	t.Run("updateTag():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:          cmn.RunesWidther{},
+			LongestTag:       "♪♪♪",
+			LongestTagLength: 3,
+		}
+
+		got.updateTag("♪♪♪")
+		got.updateTag("♪♪")
+		got.updateTag("♪")
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("updateTagList():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:          cmn.RunesWidther{},
+			LongestTag:       "♪♪♪",
+			LongestTagLength: 3,
+		}
+
+		got.updateTagList([]string{"♪", "♪♪♪", "♪♪"})
+		got.updateTagList(nil)
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("updateHost():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:           cmn.RunesWidther{},
+			LongestHost:       "♪♪♪",
+			LongestHostLength: 3,
+		}
+
+		got.updateHost("♪♪♪")
+		got.updateHost("♪♪")
+		got.updateHost("♪")
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("updateHostsCount():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:    cmn.RunesWidther{},
+			HostsCount: 5,
+		}
+
+		got.updateHostsCount(2)
+		got.updateHostsCount(0)
+		got.updateHostsCount(3)
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
 	t.Run("updateWithPlay():", func(t *testing.T) {
 
 		got := Stats{Widther: cmn.RunesWidther{}}
@@ -131,11 +254,13 @@ func Test_Stats(t *testing.T) {
 			LongestPlayDescriptionLength: 12,
 			LongestPlayTags:              "♪♪♪ Tags ♪♪♪",
 			LongestPlayTagsLength:        12,
+			LongestTag:                   "♪♪♪",
+			LongestTagLength:             3,
 		}
 
-		got.updateWithPlay(&Play{"♪♪♪ Name ♪♪♪", "♪♪♪ Tags ♪♪♪"})
-		got.updateWithPlay(&Play{"♪♪ Name ♪♪", "♪♪ Tags ♪♪"})
-		got.updateWithPlay(&Play{"♪ Name ♪", "♪ Tags ♪"})
+		got.updateWithPlay(&Play{Name: "♪♪♪ Name ♪♪♪", Tags: "♪♪♪ Tags ♪♪♪", TagList: []string{"♪", "♪♪♪"}})
+		got.updateWithPlay(&Play{Name: "♪♪ Name ♪♪", Tags: "♪♪ Tags ♪♪", TagList: []string{"♪♪"}})
+		got.updateWithPlay(&Play{Name: "♪ Name ♪", Tags: "♪ Tags ♪"})
 
 		if diff := cmp.Diff(want, got); diff != "" {
 			t.Errorf("(-want +got): \n%s", diff)
@@ -155,15 +280,97 @@ func Test_Stats(t *testing.T) {
 			LongestTaskDescriptionLength: 27,
 			LongestTaskTags:              "♪♪♪ Tags ♪♪♪",
 			LongestTaskTagsLength:        12,
+			TasksCount:                   3,
+			MaxTaskTagsCount:             2,
+			MaxTaskLine:                  7,
+			LongestTag:                   "♪♪♪♪",
+			LongestTagLength:             4,
 		}
 
-		got.updateWithTask(&Task{"♪♪♪ Block ♪♪♪", "♪♪♪ Name ♪♪♪", "♪♪♪ Tags ♪♪♪"})
-		got.updateWithTask(&Task{"♪♪ Block ♪♪", "♪♪ Name ♪♪", "♪♪ Tags ♪♪"})
-		got.updateWithTask(&Task{"♪ Block ♪", "♪ Name ♪", "♪ Tags ♪"})
+		got.updateWithTask(&Task{Block: "♪♪♪ Block ♪♪♪", Name: "♪♪♪ Name ♪♪♪", Tags: "♪♪♪ Tags ♪♪♪"})
+		got.updateWithTask(&Task{Block: "♪♪ Block ♪♪", Name: "♪♪ Name ♪♪", Tags: "♪♪ Tags ♪♪", TagList: []string{"♪♪", "♪♪♪♪"}, Line: 7})
+		got.updateWithTask(&Task{Block: "♪ Block ♪", Name: "♪ Name ♪", Tags: "♪ Tags ♪", TagList: []string{"♪"}})
 
 		if diff := cmp.Diff(want, got); diff != "" {
 			t.Errorf("(-want +got): \n%s", diff)
 		}
 	})
 
+	t.Run("updatePercentiles():", func(t *testing.T) {
+
+		got := Stats{Widther: cmn.RunesWidther{}}
+		want := Stats{
+			Widther:            cmn.RunesWidther{},
+			TaskBlockLengthP90: 3,
+			TaskNameLengthP90:  4,
+			TaskTagsLengthP90:  6,
+		}
+
+		tasks := &Tasks{}
+
+		for i := 0; i < 9; i++ {
+			tasks.Tasks = append(tasks.Tasks, &Task{Block: "♪♪♪", Name: "Name", Tags: "[tags]"})
+		}
+
+		tasks.Tasks = append(tasks.Tasks, &Task{Block: "♪♪♪ Long block ♪♪♪", Name: "Long name", Tags: "[long, tags]"})
+
+		got.updatePercentiles([]*Row{{Data: Passthru("playbook: playbook.yml")}, {Indent: 6, Data: tasks}})
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
+}
+
+func Test_collectPlayStats(t *testing.T) {
+	global := &Stats{Widther: cmn.RunesWidther{}, InputFormat: FormatRun}
+
+	rows := []*Row{
+		{Data: Passthru("Leading line that counts toward no play")},
+		{Data: &RunPlay{Number: 1, Name: "Web"}},
+		{Data: &RunTask{PlayNumber: 1, Name: "Ping", Results: []*HostResult{{Host: "web01"}}}},
+		{Data: &RunPlay{Number: 2, Name: "Databases"}},
+		{Data: &RunTask{PlayNumber: 2, Block: "db", Name: "Ping all", Results: []*HostResult{{Host: "db01"}}}},
+		{Data: &Recap{Hosts: []*RecapHost{{Host: "web01.example.com"}}}},
+	}
+
+	want := []*Stats{
+		{
+			Widther:                      cmn.RunesWidther{},
+			InputFormat:                  FormatRun,
+			LongestPlayDescription:       "PLAY [Web]",
+			LongestPlayDescriptionLength: 10,
+			LongestPlayTitle:             "Web",
+			LongestPlayTitleLength:       3,
+			MaxPlayNumber:                1,
+			LongestTaskName:              "Ping",
+			LongestTaskNameLength:        4,
+			LongestTaskDescription:       "Ping",
+			LongestTaskDescriptionLength: 4,
+			LongestHost:                  "web01",
+			LongestHostLength:            5,
+		},
+		{
+			Widther:                      cmn.RunesWidther{},
+			InputFormat:                  FormatRun,
+			LongestPlayDescription:       "PLAY [Databases]",
+			LongestPlayDescriptionLength: 16,
+			LongestPlayTitle:             "Databases",
+			LongestPlayTitleLength:       9,
+			MaxPlayNumber:                2,
+			LongestTaskBlock:             "db",
+			LongestTaskBlockLength:       2,
+			LongestTaskName:              "Ping all",
+			LongestTaskNameLength:        8,
+			LongestTaskDescription:       "db: Ping all",
+			LongestTaskDescriptionLength: 12,
+			LongestHost:                  "db01",
+			LongestHostLength:            4,
+		},
+	}
+
+	if diff := cmp.Diff(want, collectPlayStats(rows, global)); diff != "" {
+		t.Errorf("(-want +got): \n%s", diff)
+	}
 }
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import "strings"
+
+// ParseTags splits raw tags, e.g. "[tag1,   tag2]", into a list of individual tags.
+//
+// Tags are trimmed, empty ones are skipped and duplicates are dropped keeping the order
+// of first appearance. Returns nil when there are no tags.
+func ParseTags(raw string) []string {
+	var result []string
+
+	value := strings.TrimSpace(raw)
+	value = strings.TrimPrefix(value, "[")
+	value = strings.TrimSuffix(value, "]")
+
+	seen := make(map[string]struct{})
+
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if tag == "" {
+			continue
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	return result
+}
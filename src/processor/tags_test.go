@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"[]", nil},
+		{"[ , ,]", nil},
+		{"[tag1]", []string{"tag1"}},
+		{"[tag1,   tag2]", []string{"tag1", "tag2"}},
+		{"  [b, a, c]  ", []string{"b", "a", "c"}},
+		{"[a, b, a, c, b]", []string{"a", "b", "c"}},
+		{"[♪, ♪♪, ♪♪♪]", []string{"♪", "♪♪", "♪♪♪"}},
+		{"tag1, tag2", []string{"tag1", "tag2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ParseTags(tt.input)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("(-want +got): \n%s", diff)
+			}
+		})
+	}
+}
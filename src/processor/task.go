@@ -7,9 +7,11 @@ package processor
 import "fmt"
 
 type Task struct {
-	Block string
-	Name  string
-	Tags  string
+	Block   string
+	Name    string
+	Tags    string   // Raw tags as printed by ansible, e.g. "[tag1, tag2]"
+	TagList []string // Parsed tags, see ParseTags
+	Line    int      // Line of the input the task is listed on
 }
 
 func (t *Task) Description() string {
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import "fmt"
+
+// TaskTags is a `TASK TAGS: [...]` line of `ansible-playbook --list-tags` output
+type TaskTags struct {
+	PlayNumber int
+	Tags       string   // Raw tags as printed by ansible, e.g. "[tag1, tag2]"
+	TagList    []string // Parsed tags, see ParseTags
+}
+
+func (tt *TaskTags) String() string {
+	return fmt.Sprintf("TASK TAGS: %s", tt.Tags)
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2023 Alexander Bugrov <abugrov+dev@gmail.com>
+//
+// SPDX-License-Identifier: MIT
+
+package processor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_TaskTags(t *testing.T) {
+	tt := &TaskTags{PlayNumber: 1, Tags: "[tag1, tag2]", TagList: []string{"tag1", "tag2"}}
+
+	t.Run("Implements 'Stringer' interface", func(t *testing.T) {
+		got := tt.String()
+		want := "TASK TAGS: [tag1, tag2]"
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+}
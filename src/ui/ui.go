@@ -34,14 +34,22 @@ func MsgBoxFunc(w io.Writer, input []string, box *cmn.BoxChars, fnWidth cmn.Widt
 	border := strings.Repeat(box.Hor, borderLen)
 	pad := strings.Repeat(" ", padding)
 
-	fmt.Fprint(w, box.CornerTL, border, box.CornerTR, "\n")
+	fnPrintBorder := func(left string, right string) {
+		line := fmt.Sprint(left, border, right)
+
+		if !box.IsOpen && !cmn.IsBlankBorder(line) {
+			fmt.Fprint(w, line, "\n")
+		}
+	}
+
+	fnPrintBorder(box.CornerTL, box.CornerTR)
 
 	for _, item := range input {
 		val := cmn.PadRightFunc(item, ' ', maxLen, fnWidth)
 		fmt.Fprint(w, box.Ver, pad, val, pad, box.Ver, "\n")
 	}
 
-	fmt.Fprint(w, box.CornerBL, border, box.CornerBR, "\n")
+	fnPrintBorder(box.CornerBL, box.CornerBR)
 }
 
 func MsgBoxTo(output io.Writer, input []string) {
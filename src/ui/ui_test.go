@@ -107,4 +107,17 @@ func TestMsgBoxFunc(t *testing.T) {
 		}
 	})
 
+	t.Run("Open boxes have no top and bottom borders", func(t *testing.T) {
+		var b, want cmn.LineBuilder
+		want.WriteLine("| 1  |")
+		want.WriteLine("| 12 |")
+
+		box := cmn.BoxCharsMarkdown()
+		MsgBoxFunc(&b, data[:2], &box, cmn.WidthRunes)
+
+		if diff := cmp.Diff(want.String(), b.String()); diff != "" {
+			t.Errorf("(-want +got): \n%s", diff)
+		}
+	})
+
 }